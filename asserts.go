@@ -0,0 +1,347 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"fmt"
+	. "go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Tests if an ExprStmt is an affirm or deny assertion, named by the given
+// keywords. Every argument is a condition, except for a trailing string
+// literal, which is the action. If an assertion cannot be inlined, the
+// reason why is returned.
+func canInlineAssert(sm *ExprStmt, affirm, deny string) (yes bool, conds []Expr, action string, name string, why string) {
+	callexpr, ok := sm.X.(*CallExpr)
+	if !ok {
+		return
+	}
+	tfnc, ok := callexpr.Fun.(*Ident)
+	if !ok {
+		return
+	}
+	name = tfnc.Name
+	if name != affirm && name != deny {
+		return
+	}
+	conds = callexpr.Args
+	if len(conds) > 1 {
+		if bl, ok := conds[len(conds)-1].(*BasicLit); ok && bl.Kind == token.STRING {
+			action = string(Trim([]byte(bl.Value), "\"`"))
+			conds = conds[:len(conds)-1]
+		}
+	}
+	if len(conds) == 0 { // There needs to be at least one condition
+		why = "it has no condition"
+		return
+	}
+	yes = true
+	return
+}
+
+// Returns the name of a *testing.T, *testing.B or testing.TB parameter
+// of the innermost enclosing function, or "" if there is none.
+func (m *BlockVisitor) testingParam() string {
+	var ft *FuncType
+	switch fn := m.enclosingFunc().(type) {
+	case *FuncDecl:
+		ft = fn.Type
+	case *FuncLit:
+		ft = fn.Type
+	default:
+		return ""
+	}
+	for _, field := range ft.Params.List {
+		typ := field.Type
+		if star, ok := typ.(*StarExpr); ok {
+			typ = star.X
+		}
+		sel, ok := typ.(*SelectorExpr)
+		if !ok {
+			continue
+		}
+		pkg, ok := sel.X.(*Ident)
+		if !ok || pkg.Name != "testing" {
+			continue
+		}
+		switch sel.Sel.Name {
+		case "T", "B", "TB":
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					return name.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Returns a t.Fatalf call reporting the failed assertion, its expression
+// text and the values of its operands. Operands containing calls are not
+// reported, since reporting them would evaluate the calls twice. If the
+// assertion has several conditions, the failed condition is reported too.
+func (m *BlockVisitor) testingAction(tName string, sm *ExprStmt, conds []Expr, index int) string {
+	src := m.Text
+	escape := func(n Node) string { // Escape verbs in the expression text
+		return string(ReplaceAll([]byte(src(n)), []byte("%"), []byte("%%")))
+	}
+	var operands []Expr
+	switch x := conds[index].(type) {
+	case *BinaryExpr:
+		operands = []Expr{x.X, x.Y}
+	case *Ident:
+		operands = []Expr{x}
+	}
+	format := escape(sm) + " failed"
+	if len(conds) > 1 {
+		format += " at condition " + strconv.Itoa(index) + " (" + escape(conds[index]) + ")"
+	}
+	args := ""
+	sep := ": "
+	for _, op := range operands {
+		if _, ok := op.(*BasicLit); ok {
+			continue // The value is already in the expression text
+		}
+		hasCall := false
+		Inspect(op, func(n Node) bool {
+			_, ok := n.(*CallExpr)
+			hasCall = hasCall || ok
+			return !hasCall
+		})
+		if hasCall {
+			continue // Do not evaluate a call a second time
+		}
+		format += sep + escape(op) + " = %v"
+		args += ", " + src(op)
+		sep = ", "
+	}
+	return tName + ".Fatalf(" + strconv.Quote(format) + args + ")"
+}
+
+// Returns the value an assertion argument is compared against when it is
+// not a boolean, or isBool if the argument is a boolean. Arguments of
+// non-nilable types are compared against their zero value. Without type
+// information, binary and unary expressions are taken to be boolean and
+// anything else is compared against nil.
+func (m *BlockVisitor) zeroValue(x Expr) (zero string, isBool bool, err error) {
+	typ := m.TypeOf(x)
+	if typ == nil || typ == types.Typ[types.Invalid] {
+		switch x.(type) {
+		case *BinaryExpr, *UnaryExpr, *ParenExpr:
+			return "", true, nil
+		}
+		return "nil", false, nil
+	}
+	qualifier := func(p *types.Package) string {
+		if p == m.pkg {
+			return ""
+		}
+		if name, ok := m.importNames[p.Path()]; ok {
+			return name
+		}
+		return p.Name()
+	}
+	typeStr := types.TypeString(typ, qualifier)
+	if _, ok := typ.(*types.TypeParam); ok {
+		if !types.Comparable(typ) {
+			return "", false, fmt.Errorf("cannot compare %s of type parameter %s with its zero value",
+				types.ExprString(x), typeStr)
+		}
+		return "*new(" + typeStr + ")", false, nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "", true, nil
+		case u.Info()&types.IsString != 0:
+			return `""`, false, nil
+		case u.Info()&types.IsNumeric != 0:
+			return "0", false, nil
+		}
+		return "nil", false, nil
+	case *types.Struct, *types.Array:
+		if !types.Comparable(typ) {
+			return "", false, fmt.Errorf("cannot compare %s of non-comparable type %s with its zero value",
+				types.ExprString(x), typeStr)
+		}
+		return "(" + typeStr + "{})", false, nil
+	}
+	return "nil", false, nil
+}
+
+// Inlines asserts with the keywords 'affirm_' or 'deny_', or those set by
+// the options. The conditions of an assertion are checked in order, and
+// the action is executed for the first one that fails. Any '$index' in
+// the action is replaced with the index of the failed condition.
+var assertInline BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
+	for _, statement := range f.List {
+		sm, ok := statement.(*ExprStmt)
+		if !ok {
+			continue
+		}
+		yes, conds, action, name, why := canInlineAssert(sm, m.affirm, m.deny)
+		if why != "" {
+			m.rejected(sm, "assert", name, why)
+		}
+		if !yes {
+			continue
+		}
+		pos := (name == m.affirm)
+		// Copies of an assertion are counted at its source position
+		counter := ""
+		if pos := m.origin(sm).Pos(); m.statsName != "" && pos.IsValid() {
+			counter = m.assertCounter(m.sourcePos(pos))
+		}
+		// Without an explicit action, asserts in test functions fail the test
+		tName := ""
+		if action == "" {
+			action = "return"
+			tName = m.testingParam()
+		}
+
+		// Write the assertion, one condition at a time
+		var check strings.Builder
+		if counter != "" {
+			check.WriteString(statsAtomic + ".AddUint64(&" + counter + ".Evals, 1)\n")
+		}
+		for i, x := range conds {
+			zero, isBool, err := m.zeroValue(x)
+			if err != nil {
+				m.Errorf(x, "%v", err)
+				return
+			}
+			src := m.Text(x)
+			var cond string
+			switch {
+			case isBool && pos:
+				cond = "(" + src + ") == false"
+			case isBool:
+				cond = src
+			case pos:
+				cond = src + " == " + zero
+			default:
+				cond = src + " != " + zero
+			}
+			condAction := strings.ReplaceAll(action, "$index", strconv.Itoa(i))
+			if tName != "" {
+				condAction = m.testingAction(tName, sm, conds, i)
+			}
+			if counter != "" {
+				condAction = statsAtomic + ".AddUint64(&" + counter + ".Fails, 1)\n" + condAction
+			}
+			if i > 0 {
+				check.WriteString(" else ")
+			}
+			check.WriteString("if " + cond + " {\n" + condAction + "\n}")
+		}
+		stmts, err := ParseStmts(check.String())
+		if err != nil {
+			m.Errorf(sm, "cannot inline %s: %v", m.Text(sm), err)
+			return
+		}
+		m.Replace(sm, "inlined assert", stmts...)
+		m.expanded(sm, "assert", name)
+	}
+}
+
+// The import names of the packages used by the assertion statistics
+const (
+	statsFmt    = "inlinerfmt"
+	statsIO     = "inlinerio"
+	statsAtomic = "inlineratomic"
+)
+
+// Returns the counter of the assertion at the source position. Copies of
+// an assertion made by inlining or unwinding share the same counter.
+func (m *BlockVisitor) assertCounter(sourcePos string) string {
+	index, ok := m.statsIndex[sourcePos]
+	if !ok {
+		index = len(m.statsPos)
+		m.statsIndex[sourcePos] = index
+		m.statsPos = append(m.statsPos, sourcePos)
+	}
+	return "assertStats_" + m.statsName + "[" + strconv.Itoa(index) + "]"
+}
+
+// Returns the file and line of a source position, counting the lines
+// trimmed from the source.
+func (m *BlockVisitor) sourcePos(pos token.Pos) string {
+	p := m.fset.Position(pos)
+	return filepath.Base(p.Filename) + ":" + strconv.Itoa(p.Line+m.lineOffsets[p.Filename])
+}
+
+// Assigns the counters of the assertions of the file in source order.
+func (m *BlockVisitor) countAsserts(f *File) {
+	Inspect(f, func(n Node) bool {
+		sm, ok := n.(*ExprStmt)
+		if !ok {
+			return true
+		}
+		if yes, _, _, _, _ := canInlineAssert(sm, m.affirm, m.deny); yes {
+			m.assertCounter(m.sourcePos(sm.Pos()))
+		}
+		return true
+	})
+}
+
+// Adds the imports used by the assertion statistics to the processed
+// source and appends the counters and a function that dumps them.
+func (m *BlockVisitor) writeAssertStats(src []byte, out io.Writer) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.PackageClauseOnly)
+	if err != nil {
+		return err
+	}
+	nameEnd := fset.Position(f.Name.End()).Offset
+	var b Buffer
+	b.Write(src[:nameEnd])
+	b.WriteString("\n\nimport (\n\t" + statsFmt + " \"fmt\"\n\t" + statsIO + " \"io\"\n\t" +
+		statsAtomic + " \"sync/atomic\"\n)\n" + m.afterPackageClause(fset, f))
+	b.Write(src[nameEnd:])
+	name := "assertStats_" + m.statsName
+	b.WriteString("\n// " + name + " counts the evaluations and failures of each inlined assertion.\n")
+	b.WriteString("var " + name + " = [...]struct {\n\tPos          string\n\tEvals, Fails uint64\n}{\n")
+	for _, sourcePos := range m.statsPos {
+		b.WriteString("\t{Pos: " + strconv.Quote(sourcePos) + "},\n")
+	}
+	b.WriteString("}\n")
+	dumpName := "dumpAssertStats_" + m.statsName
+	b.WriteString("\n// " + dumpName + " writes the counts of " + name + " to w.\n")
+	b.WriteString("func " + dumpName + "(w " + statsIO + ".Writer) {\n")
+	b.WriteString("\tfor i := range " + name + " {\n\t\ts := &" + name + "[i]\n")
+	b.WriteString("\t\t" + statsFmt + ".Fprintf(w, \"%s: evaluated %d, failed %d\\n\", s.Pos,\n")
+	b.WriteString("\t\t\t" + statsAtomic + ".LoadUint64(&s.Evals), " + statsAtomic + ".LoadUint64(&s.Fails))\n")
+	b.WriteString("\t}\n}\n")
+	_, err = out.Write(b.Bytes())
+	return err
+}
+
+// Returns the identifier suffix of the assertion statistics of a source
+// file, which is made from its base name without the extension.
+func statsIdent(sourceName string) string {
+	if sourceName == "" {
+		sourceName = "source.go"
+	}
+	base := filepath.Base(sourceName)
+	base = base[:len(base)-len(filepath.Ext(base))]
+	ident := []byte(base)
+	for i, c := range ident {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			ident[i] = '_'
+		}
+	}
+	return string(ident)
+}
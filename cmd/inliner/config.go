@@ -0,0 +1,123 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The name of the project configuration file
+const configName = ".inliner.json"
+
+// The flags that may be set by the configuration file, which are the
+// settings of a project rather than of an invocation.
+var configFlags = map[string]bool{
+	"assertstats": true, "filter": true, "enable": true, "disable": true, "maxdepth": true,
+	"maxunwind": true, "affirm": true, "deny": true, "lines": true, "clean": true, "fmt": true,
+	"tag": true, "name": true, "j": true,
+}
+
+// Returns the name of the configuration file found in the directory of the
+// path, or in the nearest directory above it, or "" if there is none.
+func findConfig(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		name := filepath.Join(dir, configName)
+		if _, err := os.Stat(name); err == nil {
+			return name, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Sets the flags of the configuration file that are not set on the
+// command line. The file holds a JSON object whose keys are the names of
+// the flags, and whose values are strings, numbers, booleans, or arrays
+// of strings for the flags taking comma separated lists, such as:
+//
+//	{"filter": "_$", "enable": ["functInline", "unwindStaticLoop"], "lines": true}
+func applyConfig(fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("%s: %v", fileName, err)
+	}
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names) // The errors are reported in a stable order
+	for _, name := range names {
+		if !configFlags[name] {
+			return fmt.Errorf("%s: unknown setting %q", fileName, name)
+		}
+		if set[name] { // The command line overrides the configuration
+			continue
+		}
+		value, err := configValue(settings[name])
+		if err == nil {
+			err = flag.Set(name, value)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: setting %q: %v", fileName, name, err)
+		}
+	}
+	return nil
+}
+
+// Returns the text of a setting as a flag value.
+func configValue(raw json.RawMessage) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		return string(bytes.TrimSpace(raw)), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return "", errors.New("a list holds strings only")
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", errors.New("the value is not a string, number, boolean or list")
+}
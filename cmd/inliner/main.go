@@ -0,0 +1,390 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+// The inliner command inlines a Go source file. It is intended to be used
+// with the Go tool's generate facility. See the inliner package.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/srwiley/Inliner"
+)
+
+func main() {
+	var outputFile, inputFile, pkgDir, fileFilter, enable, disable, tag, pattern, affirm, deny, config, report string
+	help, assertStats, explain, lines, clean, format, check, diff, write := false, false, false, false, false, true, false, false, false
+	maxDepth, maxUnwind, jobs := 0, 0, 0
+	flag.BoolVar(&help, "help", false, "Print arguments")
+	flag.BoolVar(&assertStats, "assertstats", false,
+		"Count the evaluations and failures of each inlined assertion.")
+	flag.StringVar(&outputFile, "out", "", "Name of output file, or - for the standard output, which is the default\n"+
+		"when reading the standard input.")
+	flag.StringVar(&inputFile, "in", "", "Name of input file, or comma separated names of input files, each inlined\n"+
+		"into a file named with an '_inlined' suffix. Replaces -out. Without input files,\n"+
+		"or with -, the standard input is read.")
+	flag.StringVar(&pkgDir, "pkg", "", "Directory of a package whose generate files are inlined together,\n"+
+		"each into a file named with an '_inlined' suffix. Replaces -in and -out.")
+	flag.StringVar(&fileFilter, "filter", inliner.DefaultFilter, "Regular expression to filter inlineable names.")
+	operators := strings.Join(inliner.Registered(), ", ")
+	flag.StringVar(&enable, "enable", "", "Comma separated operators to apply, of "+operators+". Defaults to all.")
+	flag.StringVar(&disable, "disable", "", "Comma separated operators not to apply.")
+	flag.IntVar(&maxDepth, "maxdepth", inliner.DefaultMaxDepth, "Maximum nesting depth of inlined code.")
+	flag.IntVar(&maxUnwind, "maxunwind", 0, "Maximum number of iterations of an unwound loop, or 0 for no limit.")
+	flag.StringVar(&affirm, "affirm", inliner.DefaultAffirm, "Name of the assertions whose conditions must hold.")
+	flag.StringVar(&deny, "deny", inliner.DefaultDeny, "Name of the assertions whose conditions must not hold.")
+	flag.BoolVar(&explain, "explain", false, "Report why each candidate function, loop, assertion and contract was\n"+
+		"or was not inlined.")
+	flag.BoolVar(&explain, "v", false, "Shorthand for -explain.")
+	flag.BoolVar(&lines, "lines", false, "Write //line directives mapping the inlined code to the lines of its source.")
+	flag.BoolVar(&clean, "clean", false, "Leave the replaced source out, rather than commenting it out.")
+	flag.BoolVar(&format, "fmt", true, "Format the output like gofmt, and remove its unused imports.")
+	flag.BoolVar(&check, "check", false, "Check that the output files are up to date, printing the differences\n"+
+		"of those that are not, without writing them.")
+	flag.BoolVar(&diff, "d", false, "Print the differences between the sources and their inlined files, each\n"+
+		"change annotated with the operators making it, without writing the files.")
+	flag.StringVar(&report, "report", "", "Print a report of the transformations and candidates of each file in the\n"+
+		"format given, which is json: a JSON object on a line for each file.")
+	flag.BoolVar(&write, "w", false, "Write the result of each input file to the file itself, in place.")
+	flag.StringVar(&tag, "tag", inliner.DefaultTag, "Build tag of the source files to inline.")
+	flag.StringVar(&pattern, "name", "*_inlined.go", "Name of the inlined file of a source file, in which * stands for the name\n"+
+		"of the source without .go. The inlined file of a test is named like a test.")
+	flag.IntVar(&jobs, "j", runtime.GOMAXPROCS(0), "Number of input files inlined at once.")
+	flag.StringVar(&config, "config", "", "Name of the configuration file, which by default is the "+configName+" file\n"+
+		"found in the directory of the first input or above it, or none. Flags override it.")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: inliner [flags] [file.go ... | dir/...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if help {
+		fmt.Println("inliner utility for Go language intended for use with go generate")
+		flag.CommandLine.PrintDefaults()
+		return
+	}
+	inputFiles, args := splitList(inputFile), flag.Args()
+	if config == "" {
+		start := "." // The directory of the first input
+		switch {
+		case len(pkgDir) > 0:
+			start = pkgDir
+		case len(inputFiles) > 0 && inputFiles[0] != "-":
+			start = inputFiles[0]
+		case len(args) > 0:
+			start = args[0]
+			if root, ok := strings.CutSuffix(start, "..."); ok {
+				start = root + "."
+			}
+		}
+		var err error
+		config, err = findConfig(start)
+		exitOnError(err)
+	}
+	if config != "" && config != "none" {
+		exitOnError(applyConfig(config))
+	}
+	opts := &inliner.Options{Filter: fileFilter, AssertStats: assertStats,
+		Enable: splitList(enable), Disable: splitList(disable), MaxDepth: maxDepth, Jobs: jobs,
+		LineDirectives: lines, Clean: clean, Format: format, Tag: tag,
+		MaxUnwind: maxUnwind, Affirm: affirm, Deny: deny}
+	if strings.Count(pattern, "*") != 1 || !strings.HasSuffix(pattern, ".go") || strings.ContainsRune(pattern, filepath.Separator) {
+		illegalArguments("the -name pattern needs one * and the .go extension")
+	}
+	if explain {
+		opts.Explain = os.Stderr
+	}
+	create := func(name string) (io.WriteCloser, error) {
+		if name == "-" {
+			return nopCloser{os.Stdout}, nil
+		}
+		return os.Create(name)
+	}
+	var roots []string // The roots of the directory trees of ./... patterns
+	for _, arg := range args {
+		if root, ok := strings.CutSuffix(arg, "..."); ok {
+			roots = append(roots, filepath.Clean(root+"."))
+		}
+	}
+	switch report {
+	case "":
+	case "json": // The standard output may hold the results
+		opts.Report = os.Stdout
+		if len(roots) == 0 && len(pkgDir) == 0 && !write && !check &&
+			(len(args) > 0 || len(inputFiles) == 0 || inputFile == "-" || outputFile == "-") {
+			opts.Report = os.Stderr
+		}
+	default:
+		illegalArguments("the -report format must be json")
+	}
+	remove := os.Remove
+	var checked checker
+	if check {
+		create, remove = checked.create, checked.remove
+	}
+	if diff {
+		opts.Diff = os.Stdout
+		create = func(string) (io.WriteCloser, error) {
+			return nopCloser{io.Discard}, nil
+		}
+		remove = nil
+	}
+	createInlined := func(name string) (io.WriteCloser, error) {
+		return create(inlinedName(pattern, name))
+	}
+	switch {
+	case len(roots) > 0:
+		if len(roots) < len(args) || len(inputFiles) > 0 || len(outputFile) > 0 || write {
+			illegalArguments("directory patterns replace files, -in, -out and -w")
+		}
+		var errs []error
+		for _, root := range roots {
+			errs = append(errs, inliner.InlineTree(root, createInlined, remove, opts))
+		}
+		exitOnError(errors.Join(errs...))
+	case len(pkgDir) != 0:
+		exitOnError(inliner.InlinePackage(pkgDir, createInlined, opts))
+	case len(inputFiles)+len(args) == 0 || len(args) == 0 && inputFile == "-":
+		if write {
+			illegalArguments("-w needs input files")
+		}
+		exitOnError(inlineStdin(outputFile, create, opts))
+	case write:
+		exitOnError(inlineInPlace(append(inputFiles, args...), create, opts))
+	case len(args) > 0:
+		if len(inputFiles) > 0 || len(outputFile) > 0 {
+			illegalArguments("file arguments replace -in and -out")
+		}
+		if check { // The files are checked against their inlined files
+			exitOnError(inliner.InlineFiles(args, createInlined, opts))
+			break
+		}
+		exitOnError(inlineToStdout(args, create, diff, opts))
+	case len(inputFiles) > 1 && len(outputFile) == 0:
+		exitOnError(inliner.InlineFiles(inputFiles, createInlined, opts))
+	case len(outputFile) == 0:
+		illegalArguments("an input file needs -out, or -w")
+	default:
+		w, err := create(outputFile)
+		exitOnError(err)
+		err = inliner.InlineFile(inputFile, w, opts)
+		if err == nil || !check { // A checked file that failed to inline is not compared
+			if cerr := w.Close(); err == nil {
+				err = cerr
+			}
+		}
+		exitOnError(err)
+	}
+	checked.exitIfStale()
+}
+
+// Inlines the standard input, which has no file name and so no header, and
+// writes the result to the output file, or to the standard output if it is
+// not named. Nothing is written if the input fails to inline.
+func inlineStdin(outputFile string, create func(string) (io.WriteCloser, error), opts *inliner.Options) error {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	var result bytes.Buffer
+	if err = inliner.Inline(src, &result, opts); err != nil {
+		return err
+	}
+	if outputFile == "" {
+		outputFile = "-"
+	}
+	w, err := create(outputFile)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bytes.TrimLeft(result.Bytes(), "\n")) // The line separating a header is not needed
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Inlines each file in place, like gofmt -w does. The result is the file
+// itself rather than a file generated from it, so it has no header, which
+// would be added again by each run. A file that fails to inline is left as
+// it is.
+func inlineInPlace(names []string, create func(string) (io.WriteCloser, error), opts *inliner.Options) error {
+	var errs []error
+	for _, name := range names {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fileOpts := *opts
+		fileOpts.SourceName = name
+		var result bytes.Buffer
+		if err = inliner.Inline(src, &result, &fileOpts); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		w, err := create(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		_, err = w.Write(bytes.TrimLeft(result.Bytes(), "\n")) // The line of a removed constraint is not needed
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Inlines the files and writes their results to the standard output in the
+// order of the names, like gofmt does, unless they are only diffed. The
+// results of the files that fail to inline are left out.
+func inlineToStdout(names []string, create func(string) (io.WriteCloser, error), diff bool, opts *inliner.Options) error {
+	results := make(map[string]*bytes.Buffer, len(names))
+	var unique []string // Each file is written once
+	for _, name := range names {
+		if results[name] == nil {
+			results[name] = new(bytes.Buffer)
+			unique = append(unique, name)
+		}
+	}
+	err := inliner.InlineFiles(unique, func(name string) (io.WriteCloser, error) {
+		if diff {
+			return create(name)
+		}
+		return nopCloser{results[name]}, nil
+	}, opts)
+	for _, name := range unique {
+		if _, werr := os.Stdout.Write(results[name].Bytes()); err == nil {
+			err = werr
+		}
+	}
+	return err
+}
+
+// Prints the usage and exits with the status of illegal arguments.
+func illegalArguments(why string) {
+	fmt.Fprintln(os.Stderr, "Illegal command arguments:", why)
+	flag.Usage()
+	os.Exit(2)
+}
+
+// Checks that the files are up to date instead of writing them. Each
+// file is compared with the existing file once it is closed, and the
+// differences of the files that are not up to date are printed.
+type checker struct {
+	sync.Mutex
+	stale []string
+}
+
+// A file written in memory, to be checked when closed
+type checkedFile struct {
+	bytes.Buffer
+	name    string
+	checker *checker
+}
+
+func (c *checker) create(name string) (io.WriteCloser, error) {
+	return &checkedFile{name: name, checker: c}, nil
+}
+
+// Returns the name of the file, which the header of an inlined file
+// names its source from.
+func (f *checkedFile) Name() string {
+	return f.name
+}
+
+func (f *checkedFile) Close() error {
+	old, err := os.ReadFile(f.name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	diff := inliner.Diff(f.name, f.name, old, f.Bytes())
+	if diff == nil {
+		return nil
+	}
+	f.checker.Lock()
+	defer f.checker.Unlock()
+	f.checker.stale = append(f.checker.stale, f.name)
+	_, err = os.Stdout.Write(diff)
+	return err
+}
+
+// A writer that is not closed, such as the standard output
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// Notes that a file would be removed, printing its removal as a diff.
+func (c *checker) remove(name string) error {
+	old, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.stale = append(c.stale, name)
+	_, err = os.Stdout.Write(inliner.Diff(name, "/dev/null", old, nil))
+	return err
+}
+
+// Exits with a non-zero status if a checked file is not up to date.
+func (c *checker) exitIfStale() {
+	if len(c.stale) > 0 {
+		sort.Strings(c.stale)
+		fmt.Fprintln(os.Stderr, "not up to date:", strings.Join(c.stale, ", "))
+		os.Exit(1)
+	}
+}
+
+// Prints the error, one line for each of joined errors, and exits with a
+// non-zero status, so that go generate stops.
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Returns the name of the inlined file of a source file by the pattern,
+// in which * stands for the base name of the source without .go, such as
+// kernel_inlined.go for kernel.go by the default pattern. The _test suffix
+// of a test is moved to the end, so that kernel_test.go is inlined into
+// kernel_inlined_test.go.
+func inlinedName(pattern, name string) string {
+	dir, base := filepath.Split(strings.TrimSuffix(name, ".go"))
+	base, test := strings.CutSuffix(base, "_test")
+	inlined := strings.Replace(pattern, "*", base, 1)
+	if test {
+		inlined = strings.TrimSuffix(inlined, ".go") + "_test.go"
+	}
+	return dir + inlined
+}
+
+// Splits a comma separated list of names
+func splitList(list string) (names []string) {
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The source of a file whose loop is unwound
+const loopSource = "//go:build generate\n\npackage p\n\nfunc f() (s int) {\n" +
+	"\tfor i_ := 0; i_ < 2; i_++ {\n\t\ts += i_\n\t}\n\treturn\n}\n"
+
+// The test binary runs the command when the variable is set, so that the
+// tests can run it with its flags and exit status.
+func TestMain(m *testing.M) {
+	if os.Getenv("INLINER_TEST_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// Runs the command in dir with the arguments and the standard input, and
+// returns its standard output and error, and its exit status.
+func runInliner(t *testing.T, dir, stdin string, args ...string) (stdout, stderr string, status int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "INLINER_TEST_MAIN=1")
+	cmd.Stdin = strings.NewReader(stdin)
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		status = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return out.String(), errOut.String(), status
+}
+
+// Writes the files, named by their slash separated paths in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	src, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(src)
+}
+
+func TestWriteInPlace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"w.go": loopSource})
+	var results []string
+	for i := 0; i < 2; i++ { // A rewritten file can be rewritten again
+		if _, stderr, status := runInliner(t, dir, "", "-w", "w.go"); status != 0 {
+			t.Fatalf("run %d exited with %d: %s", i+1, status, stderr)
+		}
+		results = append(results, readFile(t, filepath.Join(dir, "w.go")))
+	}
+	if strings.Contains(results[1], "Code generated") || !strings.HasPrefix(results[1], "package p\n") {
+		t.Errorf("file rewritten with a header:\n%s", results[1])
+	}
+	if !strings.Contains(results[1], "\ts += 1") || results[0] != results[1] {
+		t.Errorf("second run changed the file from\n%s\nto\n%s", results[0], results[1])
+	}
+}
+
+func TestStdin(t *testing.T) {
+	stdout, stderr, status := runInliner(t, t.TempDir(), loopSource)
+	if status != 0 {
+		t.Fatalf("exited with %d: %s", status, stderr)
+	}
+	if !strings.HasPrefix(stdout, "package p\n") || !strings.Contains(stdout, "\ts += 1") {
+		t.Errorf("standard input not inlined to the standard output:\n%s", stdout)
+	}
+}
+
+func TestFileArguments(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.go": loopSource,
+		"b.go": strings.Replace(loopSource, "i_ < 2", "i_ < 3", 1),
+	})
+	stdout, stderr, status := runInliner(t, dir, "", "b.go", "a.go", "b.go")
+	if status != 0 {
+		t.Fatalf("exited with %d: %s", status, stderr)
+	}
+	// Each file is written once, in the order of the arguments
+	b, a := strings.Index(stdout, "from b.go"), strings.Index(stdout, "from a.go")
+	if strings.Count(stdout, "Code generated") != 2 || b < 0 || a < b {
+		t.Errorf("files not inlined in order:\n%s", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a_inlined.go")); err == nil {
+		t.Error("inlined file written for a file argument")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"k.go": loopSource})
+	if _, stderr, status := runInliner(t, dir, "", "-in", "k.go", "-out", "k_inlined.go"); status != 0 {
+		t.Fatalf("exited with %d: %s", status, stderr)
+	}
+	if stdout, stderr, status := runInliner(t, dir, "", "-check", "-in", "k.go", "-out", "k_inlined.go"); status != 0 || stdout != "" {
+		t.Errorf("up to date file checked with status %d:\n%s%s", status, stdout, stderr)
+	}
+	inlined := readFile(t, filepath.Join(dir, "k_inlined.go"))
+	writeFiles(t, dir, map[string]string{
+		"k.go":            strings.Replace(loopSource, "i_ < 2", "i_ < 3", 1),
+		"gone_inlined.go": "// Code generated by inliner from gone.go. DO NOT EDIT.\n\npackage p\n",
+		"sub/k.go":        loopSource, // Never inlined
+	})
+	stdout, stderr, status := runInliner(t, dir, "", "-check", "-in", "k.go", "-out", "k_inlined.go")
+	if status != 1 || !strings.Contains(stdout, "+++ k_inlined.go") || !strings.Contains(stdout, "+\ts += 2") {
+		t.Errorf("stale file checked with status %d:\n%s", status, stdout)
+	}
+	if !strings.Contains(stderr, "not up to date: k_inlined.go") {
+		t.Errorf("stale file not reported: %s", stderr)
+	}
+	if readFile(t, filepath.Join(dir, "k_inlined.go")) != inlined {
+		t.Error("checked file written")
+	}
+	// The files of a tree that would be written or removed are stale
+	stdout, stderr, status = runInliner(t, dir, "", "-check", "./...")
+	if status != 1 || !strings.Contains(stdout, "+++ /dev/null") {
+		t.Errorf("tree checked with status %d:\n%s", status, stdout)
+	}
+	for _, name := range []string{"gone_inlined.go", "k_inlined.go", filepath.Join("sub", "k_inlined.go")} {
+		if !strings.Contains(stderr, name) {
+			t.Errorf("%s not reported: %s", name, stderr)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gone_inlined.go")); err != nil {
+		t.Error("checked file removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub", "k_inlined.go")); err == nil {
+		t.Error("checked file created")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		configName:              `{"clean": true, "maxunwind": 2, "disable": ["contractInline", "assertInline"]}`,
+		"a/b/k.go":              loopSource,
+		"list/" + configName:    `{"disable": ["assertInline", "unwindStaticLoop"]}`,
+		"list/k.go":             loopSource,
+		"limit/" + configName:   `{"maxunwind": 1}`,
+		"limit/k.go":            loopSource,
+		"bad/" + configName:     `{"bogus": 1}`,
+		"bad/k.go":              loopSource,
+		"badlist/" + configName: `{"disable": [1]}`,
+		"badlist/k.go":          loopSource,
+	})
+	inline := func(dir string, args ...string) string {
+		t.Helper()
+		stdout, stderr, status := runInliner(t, filepath.Join(root, dir), "", append(args, "-out", "-")...)
+		if status != 0 {
+			t.Fatalf("%s: exited with %d: %s", dir, status, stderr)
+		}
+		return stdout
+	}
+	// The file is found in a directory above the input
+	if out := inline("a/b", "-in", "k.go"); strings.Contains(out, "/* for i_") || !strings.Contains(out, "\ts += 1") {
+		t.Errorf("configuration above the input not applied:\n%s", out)
+	}
+	if out := inline("a/b", "-clean=false", "-in", "k.go"); !strings.Contains(out, "/* for i_") {
+		t.Errorf("configuration overrides the command line:\n%s", out)
+	}
+	if out := inline("a/b", "-config", "none", "-in", "k.go"); !strings.Contains(out, "/* for i_") {
+		t.Errorf("configuration applied with -config none:\n%s", out)
+	}
+	if out := inline(".", "-config", filepath.Join(root, "list", configName), "-in", filepath.Join("a", "b", "k.go")); strings.Contains(out, "\ts += 1") {
+		t.Errorf("named configuration not applied:\n%s", out)
+	}
+	// The nearest file holds a list value, and a number
+	if out := inline("list", "-in", "k.go"); strings.Contains(out, "\ts += 1") || strings.Contains(out, "/* for i_") {
+		t.Errorf("operators of the list not disabled:\n%s", out)
+	}
+	if out := inline("limit", "-in", "k.go"); strings.Contains(out, "\ts += 1") || !strings.Contains(out, "\tfor i_") {
+		t.Errorf("loop limit not applied:\n%s", out)
+	}
+	for dir, want := range map[string]string{
+		"bad":     `unknown setting "bogus"`,
+		"badlist": `setting "disable": a list holds strings only`,
+	} {
+		_, stderr, status := runInliner(t, filepath.Join(root, dir), "", "-in", "k.go", "-out", "-")
+		if status != 1 || !strings.Contains(stderr, want) || !strings.Contains(stderr, configName) {
+			t.Errorf("%s: got status %d and %q, want an error containing %q", dir, status, stderr, want)
+		}
+	}
+}
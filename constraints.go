@@ -0,0 +1,109 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"go/build/constraint"
+)
+
+// DefaultTag is the default build tag of the source files that are inlined.
+const DefaultTag = "generate"
+
+// Finds the build constraint of a source file that requires the tag, such
+// as the generate tag, among the lines before its package clause. A
+// //go:build line is preferred to the legacy // +build lines. Returns the
+// offset of the end of the last constraint line, and the constraint of the
+// inlined file, which is the constraint with the tag satisfied, or "" if
+// the inlined file is always built. The end is zero if the source does not
+// require the tag.
+func generateConstraint(src []byte, tag string) (end int, inlined string) {
+	var goBuild, plusBuild constraint.Expr
+	offset := 0
+	for offset < len(src) {
+		line := src[offset:]
+		if i := IndexByte(line, '\n'); i >= 0 {
+			line = line[:i+1]
+		}
+		text := string(TrimSpace(line))
+		if len(text) > 0 && !HasPrefix(line, []byte("//")) {
+			break // Constraints are only found in the leading line comments
+		}
+		offset += len(line)
+		x, err := constraint.Parse(text)
+		if err != nil {
+			continue
+		}
+		if constraint.IsGoBuild(text) {
+			goBuild = x
+		} else if plusBuild == nil {
+			plusBuild = x
+		} else { // The lines of a legacy constraint must all be satisfied
+			plusBuild = &constraint.AndExpr{X: plusBuild, Y: x}
+		}
+		end = offset
+	}
+	x := goBuild
+	if x == nil {
+		x = plusBuild
+	}
+	if x == nil {
+		return 0, ""
+	}
+	rest, known, value := assumeTag(x, tag)
+	switch {
+	case known && !value: // Not a generate file
+		return 0, ""
+	case known:
+		return end, ""
+	case rest.String() == x.String(): // The tag is not required
+		return 0, ""
+	}
+	return end, rest.String()
+}
+
+// Returns a build constraint simplified by satisfying the tag. If the
+// result does not depend on other tags, known is true and value is the
+// result.
+func assumeTag(x constraint.Expr, tag string) (rest constraint.Expr, known, value bool) {
+	switch x := x.(type) {
+	case *constraint.TagExpr:
+		if x.Tag == tag {
+			return nil, true, true
+		}
+		return x, false, false
+	case *constraint.NotExpr:
+		rest, known, value := assumeTag(x.X, tag)
+		if known {
+			return nil, true, !value
+		}
+		return &constraint.NotExpr{X: rest}, false, false
+	case *constraint.AndExpr, *constraint.OrExpr:
+		var left, right constraint.Expr
+		and := false
+		switch x := x.(type) {
+		case *constraint.AndExpr:
+			left, right, and = x.X, x.Y, true
+		case *constraint.OrExpr:
+			left, right = x.X, x.Y
+		}
+		l, lKnown, lValue := assumeTag(left, tag)
+		r, rKnown, rValue := assumeTag(right, tag)
+		switch {
+		case lKnown && lValue != and, rKnown && rValue != and: // A false operand of and, or a true one of or
+			return nil, true, !and
+		case lKnown:
+			return r, rKnown, rValue
+		case rKnown:
+			return l, false, false
+		case and:
+			return &constraint.AndExpr{X: l, Y: r}, false, false
+		}
+		return &constraint.OrExpr{X: l, Y: r}, false, false
+	}
+	return x, false, false
+}
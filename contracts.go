@@ -0,0 +1,133 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"fmt"
+	. "go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Tests if an ExprStmt is a 'pre_' or 'post_' contract. If a contract
+// cannot be inlined, the reason why is returned.
+func canInlineContract(sm *ExprStmt) (yes bool, callexpr *CallExpr, action string, name string, why string) {
+	callexpr, ok := sm.X.(*CallExpr)
+	if !ok {
+		return
+	}
+	tfnc, ok := callexpr.Fun.(*Ident)
+	if !ok {
+		return
+	}
+	name = tfnc.Name
+	if name != "pre_" && name != "post_" {
+		return
+	}
+	switch len(callexpr.Args) {
+	case 1:
+	case 2:
+		bl, ok := callexpr.Args[1].(*BasicLit)
+		if !ok || bl.Kind != token.STRING {
+			why = "the action is not a string literal"
+			return
+		}
+		action = string(Trim([]byte(bl.Value), "\"`"))
+	default:
+		why = fmt.Sprintf("it has %d arguments, not 1 or 2", len(callexpr.Args))
+		return
+	}
+	yes = true
+	return
+}
+
+// Inlines the 'pre_' and 'post_' contracts found at the top of a function
+// declaration. A precondition is checked on entry to the function, and a
+// postcondition is checked by a deferred function so that it can refer to
+// named results. The postcondition is not checked while the function is
+// panicking, so that the panic is passed on rather than replaced by the
+// failure of the contract. The default failure action is a panic naming
+// the contract.
+var contractInline BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
+	fd, ok := m.enclosingFunc().(*FuncDecl)
+	if !ok || fd.Body != f {
+		m.misplacedContracts(f.List)
+		return
+	}
+	for i, statement := range f.List {
+		sm, ok := statement.(*ExprStmt)
+		if !ok {
+			m.misplacedContracts(f.List[i:])
+			return
+		}
+		yes, callexpr, action, name, why := canInlineContract(sm)
+		if why != "" {
+			m.rejected(sm, "contract", name, why)
+		}
+		if !yes {
+			m.misplacedContracts(f.List[i+1:])
+			return
+		}
+		if action == "" {
+			action = "panic(" + strconv.Quote(m.Text(sm)+" failed") + ")"
+		}
+		check := "if !(" + m.Text(callexpr.Args[0]) + ") {\n" + action + "\n}"
+		if name == "post_" && returns(action) {
+			m.Errorf(sm, "cannot inline %s: the action of a postcondition cannot return, "+
+				"since it is run by a deferred function; it may assign the named results", m.Text(sm))
+			continue
+		}
+		if name == "post_" {
+			check = "defer func() {\nif p := recover(); p != nil {\npanic(p)\n}\n" + check + "\n}()"
+		}
+		stmts, err := ParseStmts(check)
+		if err != nil {
+			m.Errorf(sm, "cannot inline %s: %v", m.Text(sm), err)
+			return
+		}
+		m.Replace(sm, "inlined contract", stmts...)
+		m.expanded(sm, "contract", name)
+	}
+}
+
+// Notes that the contracts of the statements are rejected, since they are
+// not at the top of a function declaration.
+func (m *BlockVisitor) misplacedContracts(list []Stmt) {
+	if m.explained == nil {
+		return
+	}
+	for _, statement := range list {
+		if sm, ok := statement.(*ExprStmt); ok {
+			if _, _, _, name, _ := canInlineContract(sm); name == "pre_" || name == "post_" {
+				m.rejected(sm, "contract", name, "it is not at the top of a function declaration")
+			}
+		}
+	}
+}
+
+// Tests if an action has a return statement, other than in the function
+// literals it declares.
+func returns(action string) bool {
+	stmts, err := ParseStmts(action)
+	if err != nil {
+		return false // The error is reported once the contract is parsed
+	}
+	found := false
+	for _, st := range stmts {
+		Inspect(st, func(n Node) bool {
+			switch n.(type) {
+			case *ReturnStmt:
+				found = true
+			case *FuncLit:
+				return false
+			}
+			return !found
+		})
+	}
+	return found
+}
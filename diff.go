@@ -0,0 +1,273 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The number of unchanged lines around the changes of a diff hunk
+const diffContext = 3
+
+// A line of a diff: an unchanged, removed or added line, marked by ' ',
+// '-' or '+', with the indexes of the lines of the old and new text
+// preceding or at it.
+type diffLine struct {
+	kind byte
+	text []byte
+	a, b int
+}
+
+// Diff returns the differences between the old and new texts as a unified
+// diff, which names the texts by oldName and newName, or nil if the texts
+// are the same.
+func Diff(oldName, newName string, old, new []byte) []byte {
+	return annotatedDiff(oldName, newName, old, new, nil)
+}
+
+// Returns the unified diff of the texts, with the header of each hunk
+// followed by the note that annotate returns for the indexes of the first
+// and last lines of the old text that the hunk changes. A hunk that only
+// adds lines changes the lines around them.
+func annotatedDiff(oldName, newName string, old, new []byte, annotate func(first, last int) string) []byte {
+	if Equal(old, new) {
+		return nil
+	}
+	var b Buffer
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	lines := diffLines(splitLines(old), splitLines(new))
+	for start := 0; ; {
+		first, end := nextHunk(lines, start)
+		if first == end {
+			break
+		}
+		note := ""
+		if annotate != nil {
+			note = annotate(changedLines(lines[first:end]))
+		}
+		writeHunk(&b, lines[first:end], note)
+		start = end
+	}
+	return b.Bytes()
+}
+
+// Returns the range of lines of the next hunk of changes at or after start,
+// with their unchanged context, or an empty range if there are no more
+// changes. Changes separated by no more than twice the context are in the
+// same hunk.
+func nextHunk(lines []diffLine, start int) (first, end int) {
+	i := start
+	for i < len(lines) && lines[i].kind == ' ' {
+		i++
+	}
+	if i == len(lines) {
+		return i, i
+	}
+	first, end = max(i-diffContext, start), i
+	for {
+		for end < len(lines) && lines[end].kind != ' ' {
+			end++
+		}
+		next := end
+		for next < len(lines) && lines[next].kind == ' ' {
+			next++
+		}
+		if next == len(lines) || next-end > 2*diffContext {
+			return first, min(end+diffContext, len(lines))
+		}
+		end = next
+	}
+}
+
+// Returns the indexes of the first and last lines of the old text changed
+// by a hunk.
+func changedLines(hunk []diffLine) (first, last int) {
+	first, last = -1, -1
+	for _, l := range hunk {
+		switch {
+		case l.kind == ' ':
+		case first < 0 && l.kind == '-':
+			first, last = l.a, l.a
+		case first < 0: // The line before an added line is changed too
+			first, last = l.a-1, l.a
+		case l.kind == '-':
+			last = l.a
+		default:
+			last = max(last, l.a)
+		}
+	}
+	return
+}
+
+// Writes a hunk of a unified diff, with the note following its header.
+func writeHunk(b *Buffer, hunk []diffLine, note string) {
+	aLen, bLen := 0, 0
+	for _, l := range hunk {
+		if l.kind != '+' {
+			aLen++
+		}
+		if l.kind != '-' {
+			bLen++
+		}
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@", hunkRange(hunk[0].a, aLen), hunkRange(hunk[0].b, bLen))
+	if note != "" {
+		b.WriteString(" " + note)
+	}
+	b.WriteByte('\n')
+	for _, l := range hunk {
+		b.WriteByte(l.kind)
+		b.Write(l.text)
+		if !HasSuffix(l.text, []byte("\n")) {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// Returns the range of lines of a hunk header, given the index of the
+// first line. An empty range starts at the line preceding it.
+func hunkRange(index, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	if n == 1 {
+		return fmt.Sprint(index + 1)
+	}
+	return fmt.Sprintf("%d,%d", index+1, n)
+}
+
+// Splits a text into lines, each ending with its newline, if it has one.
+func splitLines(text []byte) [][]byte {
+	var lines [][]byte
+	for len(text) > 0 {
+		i := IndexByte(text, '\n') + 1
+		if i == 0 {
+			i = len(text)
+		}
+		lines = append(lines, text[:i])
+		text = text[i:]
+	}
+	return lines
+}
+
+// Returns the lines of the shortest edit script turning the lines a into
+// the lines b, found with the greedy algorithm of Myers.
+func diffLines(a, b [][]byte) []diffLine {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1) // The furthest x reached on each diagonal k = x - y
+	var trace [][]int            // The diagonals -d to d of v before each step d
+	d := 0
+	for ; ; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		done := false
+		for k := -d; k <= d && !done; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && Equal(a[x], b[y]) {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			done = x >= n && y >= m
+		}
+		if done {
+			break
+		}
+	}
+	// The script is traced back from the end of both texts
+	var rev []diffLine
+	x, y := n, m
+	for ; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || k != d && at(k-1) < at(k+1) {
+			prevK = k + 1
+		}
+		// The step to the middle point is followed by a snake of equal lines
+		midX := at(prevK)
+		if prevK == k-1 {
+			midX++
+		}
+		for x > midX {
+			x, y = x-1, y-1
+			rev = append(rev, diffLine{' ', a[x], x, y})
+		}
+		if prevK == k+1 {
+			y--
+			rev = append(rev, diffLine{'+', b[y], x, y})
+		} else {
+			x--
+			rev = append(rev, diffLine{'-', a[x], x, y})
+		}
+	}
+	for x > 0 {
+		x, y = x-1, y-1
+		rev = append(rev, diffLine{' ', a[x], x, y})
+	}
+	lines := make([]diffLine, len(rev))
+	for i, l := range rev {
+		lines[len(rev)-1-i] = l
+	}
+	return lines
+}
+
+// Writes the diff between the source, as it was parsed, and its inlined
+// output. Each hunk is annotated with the names of the operators of the
+// replacements of the source lines that it changes.
+func (m *BlockVisitor) writeDiff(w io.Writer, name string, src, result []byte) error {
+	type span struct {
+		first, last int // The indexes of the lines of the replaced statement
+		ops         map[string]bool
+	}
+	var spans []span
+	for _, e := range m.edits {
+		s := span{m.fset.Position(e.old.Pos()).Line - 1, m.fset.Position(e.old.End()).Line - 1, make(map[string]bool)}
+		e.operators(s.ops)
+		spans = append(spans, s)
+	}
+	annotate := func(first, last int) string {
+		ops := make(map[string]bool)
+		for _, s := range spans {
+			if s.first <= last && first <= s.last {
+				for op := range s.ops {
+					ops[op] = true
+				}
+			}
+		}
+		var names []string
+		for _, op := range m.opNames { // In the order they are applied
+			if ops[op] {
+				names = append(names, op)
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	d := annotatedDiff(name, name+" (inlined)", src, result, annotate)
+	if d == nil {
+		return nil
+	}
+	_, err := w.Write(d)
+	return err
+}
+
+// Adds the names of the operators of an edit and of its nested edits.
+func (e *edit) operators(names map[string]bool) {
+	if e.op != "" {
+		names[e.op] = true
+	}
+	for _, n := range e.nested {
+		n.operators(names)
+	}
+}
@@ -0,0 +1,57 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+)
+
+// InlineError is an error at a position of a source file, reported by
+// the parser or by a BlockOperator. The errors of a file are joined with
+// errors.Join, so each of them can be found with errors.As.
+type InlineError struct {
+	Pos      token.Position // The line is that of the source, including any trimmed lines
+	Operator string         // The name of the operator reporting the error, if any
+	Msg      string
+}
+
+func (e *InlineError) Error() string {
+	if e.Operator == "" {
+		return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Pos, e.Msg, e.Operator)
+}
+
+// Converts the errors of the parser to InlineErrors at positions of the
+// source, which had lineOffset lines trimmed before it was parsed.
+func parseErrors(err error, lineOffset int) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return err
+	}
+	errs := make([]error, len(list))
+	for i, e := range list {
+		pos := e.Pos
+		pos.Line += lineOffset
+		errs[i] = &InlineError{Pos: pos, Msg: e.Msg}
+	}
+	return errors.Join(errs...)
+}
+
+// Records an error of the visitor, dropping repeats of the same error,
+// which may be reported for each expansion of the same source.
+func (m *BlockVisitor) addError(err error) {
+	for _, e := range m.errs {
+		if e.Error() == err.Error() {
+			return
+		}
+	}
+	m.errs = append(m.errs, err)
+}
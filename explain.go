@@ -0,0 +1,121 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"fmt"
+	. "go/ast"
+	"go/token"
+	"io"
+	"sort"
+)
+
+// The outcome of an inlining candidate: a function, a call, a loop, an
+// assertion or a contract.
+type explanation struct {
+	pos   token.Position
+	kind  string
+	name  string
+	count int    // The number of times the candidate was expanded
+	why   string // Why the candidate was rejected, if it was
+}
+
+// Returns the explanation of the candidate at the source node of n,
+// adding it if it is not explained yet.
+func (m *BlockVisitor) candidate(n Node, kind, name string) *explanation {
+	orig := m.origin(n)
+	e, ok := m.explained[orig]
+	if !ok {
+		e = &explanation{pos: m.position(orig), kind: kind, name: name}
+		m.explained[orig] = e
+	}
+	return e
+}
+
+// Notes that the candidate was expanded, once for each copy.
+func (m *BlockVisitor) expanded(n Node, kind, name string) {
+	if m.explained != nil {
+		m.candidate(n, kind, name).count++
+	}
+}
+
+// Notes that the candidate is not expanded, and why.
+func (m *BlockVisitor) rejected(n Node, kind, name, why string) {
+	if m.explained != nil {
+		m.candidate(n, kind, name).why = why
+	}
+}
+
+// Notes the candidates that may or may not be expanded later, such as the
+// inlineable functions, which are reported even if they are never called.
+func (m *BlockVisitor) considered(n Node, kind, name string) {
+	if m.explained != nil {
+		m.candidate(n, kind, name)
+	}
+}
+
+// Notes the inlineable and rejected top level functions of the file.
+func (m *BlockVisitor) explainDecls(f *File) {
+	for _, d := range f.Decls {
+		if d, ok := d.(*FuncDecl); ok {
+			if yes, why := isTopLevelCandidate(d, m.funcNameFilter); yes {
+				m.considered(d, "func", d.Name.Name)
+			} else if why != "" {
+				m.rejected(d, "func", d.Name.Name, why)
+			}
+		}
+	}
+}
+
+// Returns the explanations of the candidates in source order.
+func (m *BlockVisitor) sortedExplanations() []*explanation {
+	es := make([]*explanation, 0, len(m.explained))
+	for _, e := range m.explained {
+		es = append(es, e)
+	}
+	sort.Slice(es, func(i, j int) bool {
+		a, b := es[i].pos, es[j].pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return es
+}
+
+// Writes the outcome of each candidate, in source order, with a single
+// call of the writer's Write method.
+func (m *BlockVisitor) writeExplanations(w io.Writer) error {
+	var b Buffer
+	for _, e := range m.sortedExplanations() {
+		outcome := "rejected: " + e.why
+		if e.why == "" {
+			verb := "inlined"
+			if e.kind == "loop" {
+				verb = "unwound"
+			}
+			switch e.count {
+			case 0:
+				outcome = "not " + verb
+				if e.kind == "func" {
+					outcome = "not called"
+				}
+			case 1:
+				outcome = verb + " 1 time"
+			default:
+				outcome = fmt.Sprintf("%s %d times", verb, e.count)
+			}
+		}
+		fmt.Fprintf(&b, "%s: %s %s: %s\n", e.pos, e.kind, e.name, outcome)
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,213 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	"fmt"
+	. "go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// Replaces a call statement with the body of the inlined function, in
+// which the parameters are substituted by the arguments of the call.
+// The references of the body of a function of an imported package are
+// rewritten for the importing file.
+func (m *BlockVisitor) doSubstitution(fNodeType *FuncType, fNodeBody *BlockStmt, refs map[*Ident]Expr,
+	sm *ExprStmt, tNode *CallExpr) {
+	var params []*Ident
+	for _, field := range fNodeType.Params.List {
+		params = append(params, field.Names...)
+	}
+	name := types.ExprString(tNode.Fun)
+	if tNode.Ellipsis.IsValid() {
+		m.rejected(sm, "call", name, "it passes a variadic argument")
+		return
+	}
+	if len(params) != len(tNode.Args) {
+		m.rejected(sm, "call", name, fmt.Sprintf("it passes %d arguments for %d parameters", len(tNode.Args), len(params)))
+		return
+	}
+	subs := make(map[string]Expr, len(params))
+	for i, param := range params {
+		subs[param.Name] = tNode.Args[i]
+	}
+	body := m.substitute(fNodeBody, subs, refs).(*BlockStmt)
+	m.Replace(sm, "inlined", spliceable(body)...)
+	m.expanded(m.expanding[len(m.expanding)-1].def, "func", name)
+}
+
+// Returns the local functions of a block or a case clause, which are
+// assigned to variables in its statement list.
+func (m *BlockVisitor) blockFuncs(n Node) (inlines []*AssignStmt) {
+	list, _ := stmtList(n)
+	for _, statement := range list {
+		if sm, ok := statement.(*AssignStmt); ok {
+			if yes, _ := isInlineable(sm, m.funcNameFilter); yes {
+				inlines = append(inlines, sm)
+			}
+		}
+	}
+	return
+}
+
+// Tests if an assignment declares an inlineable local function. If it
+// assigns a function literal to a name matching the filter, but cannot be
+// inlined, the reason why is returned.
+func isInlineable(sm *AssignStmt, fileFilter *regexp.Regexp) (yes bool, why string) {
+	if sm.Tok != token.DEFINE && sm.Tok != token.ASSIGN {
+		return
+	}
+	lh, ok := sm.Lhs[0].(*Ident)
+	if !ok || len(fileFilter.FindString(lh.Name)) == 0 || len(sm.Rhs) == 0 {
+		return
+	}
+	fLit, ok := sm.Rhs[0].(*FuncLit)
+	if !ok {
+		return
+	}
+	if len(sm.Lhs) != 1 || len(sm.Rhs) != 1 { // only single assignments allowed
+		return false, "it assigns more than one variable"
+	}
+	if fLit.Type.Results != nil {
+		return false, "it has results"
+	}
+	return true, ""
+}
+
+// Inlines calls to the local functions in scope and to the top level
+// inlineable functions. The local functions of the block are commented
+// out, since all of their calls are inlined in the same traversal.
+var functInline BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
+	for _, statement := range f.List {
+		sm, ok := statement.(*ExprStmt)
+		if !ok {
+			continue
+		}
+		callexpr, ok := sm.X.(*CallExpr)
+		if !ok {
+			continue
+		}
+		var def Node
+		var fType *FuncType
+		var fBody *BlockStmt
+		var refs map[*Ident]Expr
+		switch fun := callexpr.Fun.(type) {
+		case *Ident:
+			def, fType, fBody = m.inlineable(fun.Name)
+			if fd, ok := def.(*FuncDecl); ok && m.funcFiles[fd] != m.file {
+				var why string
+				if refs, why = m.siblingRefs(fd); why != "" {
+					m.rejected(sm, "call", fun.Name, why)
+					continue
+				}
+			}
+		case *SelectorExpr: // An exported function of an imported package
+			if fd, fdRefs := m.importedFunc(fun); fd != nil {
+				def, fType, fBody, refs = fd, fd.Type, fd.Body, fdRefs
+			}
+		}
+		if def == nil {
+			continue
+		}
+		name := types.ExprString(callexpr.Fun)
+		if cycle := m.recursion(def, name); cycle != "" {
+			m.Errorf(sm, "recursive inline candidates: %s", cycle)
+			return
+		}
+		m.expanding = append(m.expanding, expansion{def, name})
+		m.doSubstitution(fType, fBody, refs, sm, callexpr)
+		m.expanding = m.expanding[:len(m.expanding)-1]
+	}
+	for _, statement := range f.List {
+		sm, ok := statement.(*AssignStmt)
+		if !ok {
+			continue
+		}
+		name := types.ExprString(sm.Lhs[0])
+		if yes, why := isInlineable(sm, m.funcNameFilter); yes {
+			m.considered(sm, "func", name)
+			m.Replace(sm, "inlined func")
+		} else if why != "" {
+			m.rejected(sm, "func", name, why)
+		}
+	}
+}
+
+// Returns the declaration, type and body of the inlineable function with
+// the name, or a nil declaration if there is none. The local functions of
+// the innermost enclosing block shadow those of the outer blocks, which
+// shadow the top level ones.
+func (m *BlockVisitor) inlineable(name string) (Node, *FuncType, *BlockStmt) {
+	for i := len(m.scopes) - 1; i >= 0; i-- {
+		for _, assign := range m.scopes[i] {
+			if assign.Lhs[0].(*Ident).Name == name {
+				infunc := assign.Rhs[0].(*FuncLit)
+				return assign, infunc.Type, infunc.Body
+			}
+		}
+	}
+	for _, funcDecl := range m.inlineFuncs {
+		if funcDecl.Name.Name == name {
+			return funcDecl, funcDecl.Type, funcDecl.Body
+		}
+	}
+	return nil, nil, nil
+}
+
+// An inlineable function whose body is being expanded
+type expansion struct {
+	def  Node // The *AssignStmt or *FuncDecl declaring the function
+	name string
+}
+
+// Follows the calls between the inlineable functions being expanded, and
+// returns the cycle, such as "a_ -> b_ -> a_", if the declaration is
+// already being expanded, or "" if it is not.
+func (m *BlockVisitor) recursion(def Node, name string) string {
+	for i, e := range m.expanding {
+		if e.def == def {
+			var cycle []string
+			for _, e := range m.expanding[i:] {
+				cycle = append(cycle, e.name)
+			}
+			return strings.Join(append(cycle, name), " -> ")
+		}
+	}
+	return ""
+}
+
+func (bv *BlockVisitor) collectTopLevelCandidates(f *File) {
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *FuncDecl:
+			if yes, _ := isTopLevelCandidate(d, bv.funcNameFilter); yes {
+				bv.inlineFuncs = append(bv.inlineFuncs, d)
+				bv.funcFiles[d] = f
+			}
+		}
+	}
+}
+
+// Tests if a function declaration is inlineable. If its name matches the
+// filter, but it cannot be inlined, the reason why is returned.
+func isTopLevelCandidate(d *FuncDecl, fileFilter *regexp.Regexp) (yes bool, why string) {
+	if len(fileFilter.FindString(d.Name.Name)) == 0 {
+		return
+	}
+	// Only functions without receivers allowed
+	if d.Recv != nil {
+		return false, "it has a receiver"
+	}
+	// Only functions without results allowed
+	if d.Type.Results != nil {
+		return false, "it has results"
+	}
+	return true, ""
+}
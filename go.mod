@@ -0,0 +1,3 @@
+module github.com/srwiley/Inliner
+
+go 1.21
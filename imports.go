@@ -0,0 +1,477 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	. "go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An imported package, whose exported functions can be inlined into the
+// files importing it.
+type importedPkg struct {
+	name  string
+	decls map[string]bool      // The names declared at the package level
+	funcs map[string]*FuncDecl // The functions without receivers
+	files map[*FuncDecl]*File  // The files declaring the functions
+	// The selectors of the fields and methods of values, which the package
+	// is type checked for
+	selections map[*SelectorExpr]*types.Selection
+}
+
+// Returns the imported package with the path, which is loaded from source
+// on first use, or nil if the package cannot be loaded.
+func (m *BlockVisitor) importedPackage(path string) *importedPkg {
+	if p, ok := m.imported[path]; ok {
+		return p
+	}
+	m.imported[path] = nil
+	bp, err := build.Import(path, m.srcDir, 0)
+	if err != nil {
+		return nil
+	}
+	p := &importedPkg{name: bp.Name, decls: make(map[string]bool), funcs: make(map[string]*FuncDecl),
+		files: make(map[*FuncDecl]*File), selections: make(map[*SelectorExpr]*types.Selection)}
+	var files []*File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(m.fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil
+		}
+		files = append(files, f)
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *FuncDecl:
+				if d.Recv == nil {
+					p.decls[d.Name.Name] = true
+					p.funcs[d.Name.Name] = d
+					p.files[d] = f
+				}
+			case *GenDecl:
+				for _, spec := range d.Specs {
+					switch spec := spec.(type) {
+					case *ValueSpec:
+						for _, name := range spec.Names {
+							p.decls[name.Name] = true
+						}
+					case *TypeSpec:
+						p.decls[spec.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	conf := types.Config{Importer: m.importer, Error: func(error) {}}
+	conf.Check(bp.ImportPath, m.fset, files, &types.Info{Selections: p.selections})
+	m.imported[path] = p
+	return p
+}
+
+// Returns the name of the package with the path, or "" if it cannot be
+// found.
+func (m *BlockVisitor) packageName(path string) string {
+	if name, ok := m.pkgNames[path]; ok {
+		return name
+	}
+	name := ""
+	if m.pkg != nil {
+		for _, p := range m.pkg.Imports() {
+			if p.Path() == path {
+				name = p.Name()
+			}
+		}
+	}
+	if name == "" {
+		if bp, err := build.Import(path, m.srcDir, 0); err == nil {
+			name = bp.Name
+		}
+	}
+	m.pkgNames[path] = name
+	return name
+}
+
+// Returns the exported inlineable function called by a selector of an
+// imported package, and the references of its body that must be rewritten
+// for the importing file. The declaration is nil if there is no such
+// function, or if its body refers to unexported identifiers of its
+// package, which the importing file cannot refer to.
+func (m *BlockVisitor) importedFunc(sel *SelectorExpr) (*FuncDecl, map[*Ident]Expr) {
+	x, ok := sel.X.(*Ident)
+	if !ok || x.Obj != nil || !sel.Sel.IsExported() || len(m.funcNameFilter.FindString(sel.Sel.Name)) == 0 {
+		return nil, nil
+	}
+	if m.imports == nil {
+		m.imports = m.fileImports(m.file)
+	}
+	path, ok := m.imports[x.Name]
+	if !ok {
+		return nil, nil
+	}
+	p := m.importedPackage(path)
+	if p == nil {
+		return nil, nil
+	}
+	fd := p.funcs[sel.Sel.Name]
+	if fd == nil {
+		return nil, nil
+	}
+	name := x.Name + "." + sel.Sel.Name
+	if fd.Type.Results != nil {
+		m.rejected(sel, "call", name, "it has results")
+		return nil, nil
+	}
+	refs, why := m.importedRefs(p, x.Name, fd)
+	if why != "" {
+		m.rejected(sel, "call", name, why)
+		return nil, nil
+	}
+	m.inlinedFrom[path] = true
+	return fd, refs
+}
+
+// Returns the identifiers of the body of an imported function that refer
+// to its package or to its imports, mapped to the expressions that refer
+// to the same from the importing file, which is given the imports it
+// lacks. The body cannot be inlined if it refers to unexported package
+// level identifiers, selects unexported fields or methods of the types of
+// its package, or if its file has dot imports, and the reason why is
+// returned.
+func (m *BlockVisitor) importedRefs(p *importedPkg, pkgName string, fd *FuncDecl) (map[*Ident]Expr, string) {
+	fileImports, why := m.declImports(p.files[fd])
+	if why != "" {
+		return nil, why
+	}
+	// Identifiers that are not in expressions do not refer to anything
+	skip := make(map[*Ident]bool)
+	operands := make(map[*Ident]bool) // The operands of selectors
+	Inspect(fd.Body, func(n Node) bool {
+		switch x := n.(type) {
+		case *SelectorExpr:
+			skip[x.Sel] = true
+			if id, ok := x.X.(*Ident); ok {
+				operands[id] = true
+			}
+			// The fields of the types declared in the body are copied with it
+			s := p.selections[x]
+			if s != nil && !x.Sel.IsExported() && why == "" && (s.Obj().Pos() < fd.Pos() || fd.End() <= s.Obj().Pos()) {
+				kind := "method"
+				if s.Kind() == types.FieldVal {
+					kind = "field"
+				}
+				why = "it selects the " + kind + " " + x.Sel.Name + ", which is not exported"
+			}
+		case *KeyValueExpr:
+			if id, ok := x.Key.(*Ident); ok {
+				skip[id] = true // Possibly a field name; package level keys are not supported
+			}
+		case *LabeledStmt:
+			skip[x.Label] = true
+		case *BranchStmt:
+			if x.Label != nil {
+				skip[x.Label] = true
+			}
+		case *Field:
+			for _, name := range x.Names {
+				skip[name] = true
+			}
+		}
+		return true
+	})
+	if why != "" {
+		return nil, why
+	}
+	refs := make(map[*Ident]Expr)
+	Inspect(fd.Body, func(n Node) bool {
+		id, isIdent := n.(*Ident)
+		if !isIdent || skip[id] || why != "" {
+			return why == ""
+		}
+		if id.Obj != nil && fd.Pos() <= id.Obj.Pos() && id.Obj.Pos() < fd.End() {
+			return true // A parameter or a local declaration
+		}
+		switch path, isImport := fileImports[id.Name]; {
+		case p.decls[id.Name]:
+			if !id.IsExported() {
+				why = "it refers to " + id.Name + ", which is not exported"
+				return false
+			}
+			refs[id] = &SelectorExpr{X: NewIdent(pkgName), Sel: NewIdent(id.Name)}
+		case isImport && operands[id]:
+			name := m.importName(path)
+			if name == "" {
+				why = "it refers to " + path + ", which cannot be imported by its name"
+				return false
+			}
+			if name != id.Name {
+				refs[id] = NewIdent(name)
+			}
+		}
+		return true
+	})
+	return refs, why
+}
+
+// Returns the paths of the imports of the file declaring an inlined
+// function by their local names, or the reason why the function cannot be
+// inlined.
+func (m *BlockVisitor) declImports(f *File) (map[string]string, string) {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err.Error()
+		}
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else {
+			name = m.packageName(path)
+		}
+		if name == "." {
+			return nil, "its file has a dot import"
+		}
+		imports[name] = path
+	}
+	return imports, ""
+}
+
+// Returns the identifiers of the body of a top level function declared in
+// another file of the package that refer to the imports of its file,
+// mapped to the names that the inlined file refers to the same packages
+// by, which is given the imports it lacks. The body cannot be inlined if
+// its file has dot imports, or if it refers to a package that cannot be
+// imported by its name, and the reason why is returned.
+func (m *BlockVisitor) siblingRefs(fd *FuncDecl) (map[*Ident]Expr, string) {
+	if m.imports == nil {
+		m.imports = m.fileImports(m.file)
+	}
+	fileImports, why := m.declImports(m.funcFiles[fd])
+	if why != "" {
+		return nil, why
+	}
+	refs := make(map[*Ident]Expr)
+	Inspect(fd.Body, func(n Node) bool {
+		sel, ok := n.(*SelectorExpr)
+		if !ok || why != "" {
+			return why == ""
+		}
+		// Package names are not resolved, unlike the declarations that
+		// shadow them
+		id, ok := sel.X.(*Ident)
+		if !ok || id.Obj != nil {
+			return true
+		}
+		path, ok := fileImports[id.Name]
+		if !ok {
+			return true
+		}
+		name := m.importName(path)
+		if name == "" {
+			why = "it refers to " + path + ", which cannot be imported by its name"
+			return false
+		}
+		if name != id.Name {
+			refs[id] = NewIdent(name)
+		}
+		return true
+	})
+	return refs, why
+}
+
+// Returns the name that the inlined file refers to the package with the
+// path by, adding an import of the package if it is not imported yet, or
+// "" if the package cannot be imported under its own name.
+func (m *BlockVisitor) importName(path string) string {
+	for name, p := range m.imports {
+		if p == path {
+			return name
+		}
+	}
+	name := m.packageName(path)
+	if _, taken := m.imports[name]; taken || name == "" {
+		return ""
+	}
+	m.imports[name] = path // The imports of the file have been read by now
+	m.addImports = append(m.addImports, strconv.Quote(path))
+	return name
+}
+
+// Returns the local names of the imports of a file by their paths.
+func (m *BlockVisitor) fileImports(f *File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			imports[spec.Name.Name] = path
+		} else if name := m.packageName(path); name != "" {
+			imports[name] = path
+		}
+	}
+	return imports
+}
+
+// Adds the imports needed by the inlined functions of imported packages
+// to the inlined source, and removes the imports of those packages that
+// are no longer used. If the output is formatted, the packages that it
+// refers to without importing them are imported, and every unused import
+// is removed, except for blank and dot imports and those of packages that
+// cannot be found.
+func (m *BlockVisitor) fixImports(src []byte) ([]byte, error) {
+	if len(m.addImports) == 0 && len(m.inlinedFrom) == 0 && !m.format {
+		return src, nil
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool) // The names of the imports used
+	Inspect(f, func(n Node) bool {
+		if sel, ok := n.(*SelectorExpr); ok {
+			if id, ok := sel.X.(*Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	type cut struct {
+		start, end int
+		restore    string // The directive replacing the cut lines
+	}
+	if m.format {
+		m.addMissing(f, used)
+	}
+	tf := fset.File(f.Pos())
+	var cuts []cut
+	for _, d := range f.Decls {
+		d, ok := d.(*GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			continue
+		}
+		var unused []Node
+		for _, spec := range d.Specs {
+			spec := spec.(*ImportSpec)
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := m.packageName(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			switch {
+			case used[name], name == "", name == "_", name == ".", path == "C":
+			case m.inlinedFrom[path] || m.format:
+				unused = append(unused, spec)
+			}
+		}
+		// The whole declaration is removed if none of its imports is used
+		if len(unused) == len(d.Specs) && len(unused) > 0 {
+			unused = []Node{d}
+		}
+		for _, n := range unused {
+			// The lines of the import are removed
+			start := fset.Position(n.Pos()).Offset
+			start = LastIndexByte(src[:start], '\n') + 1
+			end := fset.Position(n.End()).Offset
+			if i := IndexByte(src[end:], '\n'); i >= 0 {
+				end += i + 1
+			} else {
+				end = len(src)
+			}
+			// The lines following the cut lines keep their positions
+			restore := ""
+			if d := m.directiveOf(fset.Position(tf.Pos(end))); d != "" && end < len(src) {
+				restore = d + "\n"
+			}
+			cuts = append(cuts, cut{start, end, restore})
+		}
+	}
+	// The new imports follow the package clause
+	at := fset.Position(f.Name.End()).Offset
+	var b Buffer
+	b.Write(src[:at])
+	sort.Strings(m.addImports)
+	for _, spec := range m.addImports {
+		b.WriteString("\n\nimport " + spec)
+	}
+	if d := m.afterPackageClause(fset, f); d != "" && len(m.addImports) > 0 {
+		b.WriteString("\n" + d)
+	}
+	cursor := at
+	for _, c := range cuts {
+		b.Write(src[cursor:c.start])
+		b.WriteString(c.restore)
+		cursor = c.end
+	}
+	b.Write(src[cursor:])
+	return b.Bytes(), nil
+}
+
+// Adds the imports of the packages that the names used by an inlined file
+// refer to, but that the file does not import and the package does not
+// declare. A name is the name of an import of another file of the package,
+// which is imported the same way, or else the name of a standard library
+// package whose path is the name, such as math or sort.
+func (m *BlockVisitor) addMissing(f *File, used map[string]bool) {
+	imported := make(map[string]bool)
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			imported[spec.Name.Name] = true
+		} else {
+			imported[m.packageName(path)] = true
+		}
+	}
+	for _, spec := range m.addImports { // Not yet in the file
+		if i := strings.IndexByte(spec, ' '); i >= 0 {
+			imported[spec[:i]] = true
+		} else {
+			path, _ := strconv.Unquote(spec)
+			imported[m.packageName(path)] = true
+		}
+	}
+	var missing []string
+	for name := range used {
+		if !imported[name] && (m.pkg == nil || m.pkg.Scope().Lookup(name) == nil) && types.Universe.Lookup(name) == nil {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		spec := ""
+		for _, pf := range m.pkgFiles {
+			for _, s := range pf.Imports {
+				path, err := strconv.Unquote(s.Path.Value)
+				if err != nil || spec != "" {
+					continue
+				}
+				if s.Name != nil && s.Name.Name == name {
+					spec = name + " " + s.Path.Value
+				} else if s.Name == nil && m.packageName(path) == name {
+					spec = s.Path.Value
+				}
+			}
+		}
+		if spec == "" {
+			if bp, err := build.Import(name, m.srcDir, 0); err == nil && bp.Goroot && bp.Name == name {
+				spec = strconv.Quote(name)
+			}
+		}
+		if spec != "" {
+			m.addImports = append(m.addImports, spec)
+		}
+	}
+}
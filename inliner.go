@@ -3,480 +3,1212 @@
 // Use of this source code is governed by
 // the GNU GENERAL PUBLIC LICENSE
 // found in the LICENSE file.
-package main
+
+// Package inliner inlines simple functions, unwinds loops with static
+// integer bounds, and expands assertions and contracts in Go source code.
+// It is the library behind the inliner command, which is intended to be
+// used with the Go tool's generate facility.
+package inliner
 
 import (
 	. "bytes"
-	"flag"
+	"errors"
 	"fmt"
 	. "go/ast"
+	"go/format"
+	"go/importer"
 	"go/parser"
+	"go/printer"
 	"go/token"
+	"go/types"
 	"io"
 	"io/ioutil"
-	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 )
 
+// BlockVisitor walks the syntax tree of a file, applying its BlockOperators
+// to each code block. The operators transform the tree by replacing its
+// statements with new ones, which are printed with go/printer in place of
+// the source of the replaced statements, along with the comments of the
+// statements they copy. All other source, including its comments, is left
+// as it is. The new statements are walked as soon as they replace the old
+// ones, so that nested inlines are expanded in a single traversal of the
+// file.
 type BlockVisitor struct {
-	sbytes       Buffer // Holds the source bytes
-	sourceCursor int    // Cursor for the source bytes
-	pbytes       Buffer // Receives the processed bytes
+	fset     *token.FileSet
+	tfile    *token.File
+	src      []byte
+	edits    []*edit       // The replacements made in the walked statements
+	replaced map[Stmt]bool // Statements that have been replaced
+	origins  map[Node]Node // Copies made by Substitute to the nodes they copy
+	stack    []Node        // The nodes enclosing the visited node
+	depth    int           // The nesting depth of the walked replacements
+	maxDepth int
+	lines    bool // Whether //line directives are written
+	clean    bool // Whether originals are left out rather than commented out
+	format   bool // Whether the output is formatted and its unused imports removed
+	// The limit of iterations of an unwound loop, if not zero
+	maxUnwind int
+	// The names of the assertions that must hold and must not hold
+	affirm, deny string
+	// The inlineable functions being expanded, outermost first
+	expanding []expansion
+	// The local functions of the blocks of the stack, by their levels
+	scopes [][]*AssignStmt
 	// This regexp is used to filter function and variable
 	// names for inlining candidates.
 	funcNameFilter *regexp.Regexp
 	blockOperators []BlockOperator
+	opNames        []string // The names of the operators, if registered
+	operator       string   // The name of the operator being applied
 	inlineFuncs    []*FuncDecl
+	funcFiles      map[*FuncDecl]*File // The files declaring the top level candidates
+	// Type information of the source
+	info        *types.Info
+	pkg         *types.Package
+	pkgFiles    []*File           // The files of the package, whose imports name packages
+	importNames map[string]string // Import paths to local names of renamed imports
+	importer    types.Importer
+	errs        []error // The errors reported by the operators
+	// The inlining candidates by their source nodes; nil unless explaining
+	explained map[Node]*explanation
+	// The imported packages loaded and the names of packages, by path
+	imported map[string]*importedPkg
+	pkgNames map[string]string
+	// The imports of the source, by local name, and the imports changed
+	file        *File
+	srcDir      string
+	imports     map[string]string
+	addImports  []string
+	inlinedFrom map[string]bool
+	// The sources of the files of the package and their comments, which
+	// are printed with the statements copied from them
+	srcs     map[*token.File][]byte
+	comments []*Comment
+	// Assertion statistics; statsName is empty if assertions are not counted
+	statsName   string
+	lineOffsets map[string]int // The number of lines trimmed from each source file
+	statsPos    []string       // The source position of each counter
+	statsIndex  map[string]int // Source positions to counter indexes
 }
 
-type SubVisitor struct {
-	inlines []*AssignStmt // the rhs of the assignment is an inlinable function
-	bv      *BlockVisitor
+// An edit replaces an old statement with new statements. If the note is
+// not empty, the original source is kept in a comment ending with the note.
+// The nested edits replace statements within the new statements.
+type edit struct {
+	old    Stmt
+	new    []Stmt
+	note   string
+	op     string // The name of the operator making the edit, if known
+	nested []*edit
+	// The lines of the rendered edit, counted from the first line of the
+	// rendering of the enclosing edit, or of the output: the line that the
+	// text starts on, and the first and last lines of the new statements.
+	// The statements start on line stmtLine of the text.
+	rendered                     bool
+	line0, first, last, stmtLine int
 }
 
-type ParamVisitor struct {
-	subs             map[string][]byte
-	templatePosition int
-	bv               *BlockVisitor
+// BlockOperator functions operate on code blocks and might replace
+// statements of the block by calling the BlockVisitor's Replace method.
+// The statement lists of case clauses are passed to the operators as
+// blocks too. Every operator is applied to each block, and the children
+// of a replaced statement are not visited, since its replacement is
+// visited instead. These are plugins-like
+// functions that can be added to expand inliner's abilities, either by
+// registering them or by passing them in Options. Currently, there are
+// four registered BlockOperators; functInline, unwindStaticLoop,
+// contractInline and assertInline.
+type BlockOperator func(st *BlockStmt, m *BlockVisitor)
+
+// Options configure Inline and InlineFile. A nil *Options is the same as
+// a zero Options.
+type Options struct {
+	// Filter is a regular expression matching the names of inlineable
+	// functions and unwindable loop counters. If empty, names ending with
+	// an underscore match.
+	Filter string
+	// Operators are applied in order to each code block. If nil, the
+	// registered operators selected by Enable and Disable are applied.
+	Operators []BlockOperator
+	// Enable names the registered operators to apply. If empty, all of
+	// them are applied except for those named by Disable.
+	Enable  []string
+	Disable []string
+	// SourceName is the name of the source file, which positions in the
+	// output, such as those of counted assertions, are relative to.
+	SourceName string
+	// AssertStats instruments every inlined assertion with counters of
+	// its evaluations and failures.
+	AssertStats bool
+	// MaxDepth limits how deeply replacements may be nested within each
+	// other, such as inlined functions calling inlined functions. If zero,
+	// DefaultMaxDepth is used.
+	MaxDepth int
+	// Jobs limits the number of files that InlineFiles inlines at once. If
+	// zero, GOMAXPROCS files are inlined at once.
+	Jobs int
+	// Explain, if not nil, is written a line for each inlining candidate
+	// of a file, giving its position and its outcome: the number of times
+	// it was inlined, or why it was rejected. The lines of each file are
+	// written at once.
+	Explain io.Writer
+	// LineDirectives writes //line directives into the inlined source,
+	// so that compiler errors, stack traces and coverage refer to the
+	// lines of the source, or of the inlined functions for their bodies.
+	// The source must be named by SourceName.
+	LineDirectives bool
+	// Clean leaves the replaced source out of the output, rather than
+	// keeping it in comments. Each replacement is followed by a short
+	// comment, such as "/* inlined add_ */", unless line directives are
+	// written. The declarations of inlined local functions are removed.
+	Clean bool
+	// Format formats the inlined source with go/format, after removing
+	// the imports that it no longer uses, so that no separate gofmt step
+	// is needed.
+	Format bool
+	// MaxUnwind limits the number of iterations of an unwound loop. Loops
+	// with more iterations are left as they are. If zero, there is no limit.
+	MaxUnwind int
+	// Affirm and Deny are the names of the assertions whose conditions
+	// must hold and must not hold. If empty, DefaultAffirm and DefaultDeny
+	// are used.
+	Affirm, Deny string
+	// Tag is the build tag of the source files to inline, whose build
+	// constraint is left out of the output. If empty, DefaultTag is used.
+	Tag string
+	// Diff, if not nil, is written the unified diff between each source,
+	// without the lines of its build constraint, and its inlined output.
+	// The header of each hunk is followed by the names of the operators
+	// that made the changes. The diff of each file is written at once.
+	Diff io.Writer
+	// Report, if not nil, is written a Report of each source in JSON, on a
+	// line of its own, which is written at once.
+	Report io.Writer
 }
 
-// BlockOperator functions operate on code blocks and might advance the
-// BlockVisitor's sourceCursor variable when called, signifying that the
-// block has been written to into the pbytes buffer with whatever
-// modifications the operator creates. These are plugins-like functions
-// that can be added to expand inliner's abilities. Currently, there are
-// three BlockOperators defined; functInline, unwindStaticLoop and assertInline.
-type BlockOperator func(st *BlockStmt, m *BlockVisitor)
+// DefaultFilter matches names ending with an underscore.
+const DefaultFilter = "_$"
+
+// The default names of the assertions
+const (
+	DefaultAffirm = "affirm_"
+	DefaultDeny   = "deny_"
+)
+
+// Returns the build tag of the source files to inline.
+func (opts *Options) tag() string {
+	if opts.Tag == "" {
+		return DefaultTag
+	}
+	return opts.Tag
+}
+
+// DefaultMaxDepth is the default nesting limit of replacements. It is a
+// backstop for runaway expansions that recursion detection cannot see.
+const DefaultMaxDepth = 100
+
+// Filter returns the regular expression filtering the names of inlining
+// candidates.
+func (m *BlockVisitor) Filter() *regexp.Regexp {
+	return m.funcNameFilter
+}
+
+// Replace replaces the old statement with the new statements, which may
+// be built with Substitute or ParseStmts. Unless the note is empty, the
+// old statement is kept in a comment ending with the note. The new
+// statements are walked right away, in the scope of the old statement.
+// A statement that has already been replaced is left as it is.
+func (m *BlockVisitor) Replace(old Stmt, note string, new ...Stmt) {
+	if m.replaced[old] {
+		return
+	}
+	m.replaced[old] = true
+	if m.depth == m.maxDepth {
+		m.Errorf(old, "replacements nested more than %d deep", m.maxDepth)
+		return
+	}
+	e := &edit{old: old, new: new, note: note, op: m.operator}
+	outer := m.edits
+	m.edits = nil
+	m.depth++
+	Walk(m, &BlockStmt{List: new})
+	m.depth--
+	e.nested = m.edits
+	m.edits = append(outer, e)
+}
+
+// Text returns the source text of a node. Nodes made by the operators,
+// which have no position, are printed instead.
+func (m *BlockVisitor) Text(n Node) string {
+	if !n.Pos().IsValid() {
+		var b strings.Builder
+		if err := printConfig.Fprint(&b, token.NewFileSet(), n); err != nil {
+			m.addError(err)
+		}
+		return b.String()
+	}
+	return string(m.src[m.tfile.Offset(n.Pos()):m.tfile.Offset(n.End())])
+}
+
+// TypeOf returns the type of an expression, or nil if it could not be
+// type checked. The type of a copy made by Substitute is the type of the
+// expression it copies.
+func (m *BlockVisitor) TypeOf(x Expr) types.Type {
+	if m.info == nil {
+		return nil
+	}
+	return m.info.TypeOf(m.origin(x).(Expr))
+}
+
+// Errorf reports an *InlineError at the source position of a node, naming
+// the operator being applied. The walk goes on, so that every error of the
+// file is reported, but no output is written for a file with errors.
+func (m *BlockVisitor) Errorf(n Node, format string, args ...interface{}) {
+	m.addError(&InlineError{Pos: m.position(n), Operator: m.operator, Msg: fmt.Sprintf(format, args...)})
+}
+
+// Returns the position in the source of a node or of the node a copy made
+// by Substitute was copied from, counting the lines trimmed from the source.
+func (m *BlockVisitor) position(n Node) token.Position {
+	pos := m.fset.Position(m.origin(n).Pos())
+	pos.Line += m.lineOffsets[pos.Filename]
+	return pos
+}
+
+// Returns the node of the source that a copy made by Substitute was
+// copied from, or the node itself if it is not a copy.
+func (m *BlockVisitor) origin(n Node) Node {
+	if orig, ok := m.origins[n]; ok {
+		return orig
+	}
+	return n
+}
 
 func (m *BlockVisitor) Visit(n Node) Visitor {
-	if n == nil {
+	if n == nil { // The children of the top of the stack have been visited
+		m.stack = m.stack[:len(m.stack)-1]
+		m.scopes = m.scopes[:len(m.scopes)-1]
 		return nil
 	}
-	switch st := n.(type) {
-	case *BlockStmt:
-		for _, blockOperator := range m.blockOperators {
-			curPos := m.sourceCursor
-			blockOperator(st, m)
-			if curPos != m.sourceCursor { // block was altered
-				return nil // Do not visit children
+	if st, ok := n.(Stmt); ok && m.replaced[st] {
+		return nil // The replacement is visited instead
+	}
+	m.stack = append(m.stack, n)
+	m.scopes = append(m.scopes, m.blockFuncs(n))
+	if list, ok := stmtList(n); ok {
+		block, ok := n.(*BlockStmt)
+		if !ok {
+			block = &BlockStmt{List: list}
+		}
+		outer := m.operator // Restored, since Replace walks the replacements
+		for i, blockOperator := range m.blockOperators {
+			if i < len(m.opNames) {
+				m.operator = m.opNames[i]
 			}
+			blockOperator(block, m)
 		}
+		m.operator = outer
 	}
 	return m
 }
 
-func (m *ParamVisitor) Visit(n Node) Visitor {
-	switch st := n.(type) {
-	case *Ident:
-		subStr := m.subs[st.Name]
-		if subStr != nil {
-			if m.templatePosition < int(st.Pos()-1) {
-				m.bv.pbytes.Write(m.bv.sbytes.Bytes()[m.templatePosition : st.Pos()-1])
-				m.templatePosition = int(st.End() - 1)
-			}
-			m.bv.pbytes.WriteByte('(')
-			m.bv.pbytes.Write(subStr)
-			m.bv.pbytes.WriteByte(')')
+// Returns the statement list of a block or a case clause.
+func stmtList(n Node) ([]Stmt, bool) {
+	switch n := n.(type) {
+	case *BlockStmt:
+		return n.List, true
+	case *CaseClause:
+		return n.Body, true
+	case *CommClause:
+		return n.Body, true
+	}
+	return nil, false
+}
+
+// Returns the innermost FuncDecl or FuncLit enclosing the visited node,
+// or nil if there is none.
+func (m *BlockVisitor) enclosingFunc() Node {
+	for i := len(m.stack) - 1; i >= 0; i-- {
+		switch fn := m.stack[i].(type) {
+		case *FuncDecl, *FuncLit:
+			return fn
 		}
 	}
-	return m
+	return nil
 }
 
-func (m *SubVisitor) doSubstitution(fNodeType *FuncType, fNodeBody *BlockStmt, tNode *CallExpr) {
-	if len(fNodeType.Params.List) != len(tNode.Args) {
-		return
+// Substitute returns a deep copy of a node without positions, so that the
+// copy can be printed anywhere. Identifiers named in subs are replaced by
+// their substitutes, which are parenthesized unless they are operands. A
+// substitute without positions may be shared by several copies, so the
+// copies must not be changed. Selectors, field names, labels and the keys
+// of struct literals are not replaced. The visitor remembers the nodes
+// that were copied, so that the copies have their type and source
+// position.
+func (m *BlockVisitor) Substitute(n Node, subs map[string]Expr) Node {
+	return m.substitute(n, subs, nil)
+}
+
+// Substitutes like Substitute, and also replaces the identifiers of refs
+// whatever their names.
+func (m *BlockVisitor) substitute(n Node, subs map[string]Expr, refs map[*Ident]Expr) Node {
+	// Only identifiers in expressions are replaced. Selectors, field names
+	// and labels are identifiers of their own, but struct literal keys are
+	// expressions.
+	var keep map[*Ident]bool
+	if len(subs) > 0 {
+		keep = make(map[*Ident]bool)
+		Inspect(n, func(n Node) bool {
+			x, ok := n.(*CompositeLit)
+			if !ok {
+				return true
+			}
+			switch x.Type.(type) {
+			case *MapType, *ArrayType:
+			default: // The keys are field names
+				for _, elt := range x.Elts {
+					if kv, ok := elt.(*KeyValueExpr); ok {
+						if key, ok := kv.Key.(*Ident); ok {
+							keep[key] = true
+						}
+					}
+				}
+			}
+			return true
+		})
 	}
-	subset := make(map[string][]byte, len(fNodeType.Params.List))
-	for i, v := range fNodeType.Params.List {
-		s := tNode.Args[i]
-		subset[v.Names[0].Name] = m.bv.sbytes.Bytes()[s.Pos()-1 : s.End()-1]
-	}
-	// Write up to the function call
-	if m.bv.sourceCursor < int(tNode.Pos())-1 {
-		m.bv.pbytes.Write(m.bv.sbytes.Bytes()[m.bv.sourceCursor : tNode.Pos()-1])
-		m.bv.sourceCursor = int(tNode.Pos()) - 1
-	}
-	m.bv.sourceCursor = int(tNode.End()) - 1 // Skips the rest of target tNode
-	i := int(fNodeBody.Pos())
-loop: // Advance past the first \n and \t's of the inline function; irrelevant if the
-	//  generator calls go fmt
-	for ; ; i++ {
-		switch m.bv.sbytes.Bytes()[i] {
-		case '\t', '\n':
-		default:
-			break loop
-		}
-	}
-	pv := &ParamVisitor{subset, i, m.bv}
-	Walk(pv, fNodeBody)
-	if pv.templatePosition < int(fNodeBody.End())-1 {
-		m.bv.pbytes.Write(TrimRight(
-			m.bv.sbytes.Bytes()[pv.templatePosition:int(fNodeBody.End())-2], "\n\t"))
-		m.bv.pbytes.WriteString(" // inlined ")
-		m.bv.pbytes.Write(m.bv.sbytes.Bytes()[tNode.Pos()-1 : tNode.End()-1])
-	}
-}
-
-func (m *SubVisitor) Visit(n Node) Visitor {
-	switch st := n.(type) {
-	case *CallExpr:
-		tfnc, ok := st.Fun.(*Ident)
-		if !ok {
-			break
-		}
-		for _, assign := range m.inlines {
-			lh, _ := assign.Lhs[0].(*Ident)
-			if lh.Name == tfnc.Name {
-				infunc, ok := assign.Rhs[0].(*FuncLit)
-				if !ok { // This should have been pre-checked and never fire
-					continue
+	var copyValue func(v reflect.Value) reflect.Value
+	copyValue = func(v reflect.Value) reflect.Value {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return v
+			}
+			switch v.Type() {
+			case objectType, scopeType, commentType: // Resolution and comments are not copied
+				return reflect.Zero(v.Type())
+			}
+			c := reflect.New(v.Type().Elem())
+			c.Elem().Set(v.Elem()) // Copies the fields holding values
+			for _, i := range refFields(v.Type().Elem()) {
+				f := c.Elem().Field(i)
+				if f.Type() == posType {
+					f.SetInt(int64(token.NoPos))
+				} else {
+					f.Set(copyValue(f))
 				}
-				m.doSubstitution(infunc.Type, infunc.Body, st)
 			}
-		}
-		for _, funcDecl := range m.bv.inlineFuncs {
-			if funcDecl.Name.Name == tfnc.Name {
-				m.doSubstitution(funcDecl.Type, funcDecl.Body, st)
+			if orig, ok := v.Interface().(Node); ok && m != nil {
+				m.origins[c.Interface().(Node)] = m.origin(orig)
 			}
+			return c
+		case reflect.Interface:
+			if v.IsNil() {
+				return v
+			}
+			if id, ok := v.Interface().(*Ident); ok && !keep[id] {
+				if ref, ok := refs[id]; ok {
+					return asType(m.shared(ref), v.Type())
+				}
+				if sub, ok := subs[id.Name]; ok {
+					return asType(parenthesize(m.shared(sub)), v.Type())
+				}
+			}
+			return asType(copyValue(v.Elem()).Interface(), v.Type())
+		case reflect.Slice:
+			if v.IsNil() {
+				return v
+			}
+			c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(copyValue(v.Index(i)))
+			}
+			return c
 		}
+		return v
 	}
-	return m
+	return copyValue(reflect.ValueOf(n)).Interface().(Node)
 }
 
-func isInlineable(sm *AssignStmt, fileFilter *regexp.Regexp) (yes bool) {
-	if len(sm.Lhs) != 1 { // only single assignments allowed
-		return
+// Returns a substitute to be placed in a copy. Since the operators never
+// change expressions, a substitute made by the operators or copied, which
+// has no positions, is shared by the copies rather than copied again. The
+// nodes of the source are copied, as are function literals, whose
+// statements are replaced by their own edits in each copy.
+func (m *BlockVisitor) shared(x Expr) Expr {
+	share := true
+	Inspect(x, func(n Node) bool {
+		if _, isFunc := n.(*FuncLit); isFunc || n != nil && n.Pos().IsValid() {
+			share = false
+		}
+		return share
+	})
+	if !share {
+		return m.Substitute(x, nil).(Expr)
 	}
-	if sm.Tok != token.DEFINE && sm.Tok != token.ASSIGN {
-		return
+	return x
+}
+
+var (
+	posType     = reflect.TypeOf(token.NoPos)
+	objectType  = reflect.TypeOf((*Object)(nil))
+	scopeType   = reflect.TypeOf((*Scope)(nil))
+	commentType = reflect.TypeOf((*CommentGroup)(nil))
+	exprType    = reflect.TypeOf((*Expr)(nil)).Elem()
+	stmtType    = reflect.TypeOf((*Stmt)(nil)).Elem()
+	declType    = reflect.TypeOf((*Decl)(nil)).Elem()
+	specType    = reflect.TypeOf((*Spec)(nil)).Elem()
+)
+
+// The indexes of the fields of node types that Substitute cannot copy
+// as they are: positions, pointers, interfaces and slices.
+var refFieldCache sync.Map
+
+func refFields(t reflect.Type) []int {
+	if fields, ok := refFieldCache.Load(t); ok {
+		return fields.([]int)
 	}
-	lh, ok := sm.Lhs[0].(*Ident)
-	if !ok || len(fileFilter.FindString(lh.Name)) == 0 {
-		return
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		switch f := t.Field(i).Type; {
+		case f == posType, f.Kind() == reflect.Ptr, f.Kind() == reflect.Interface, f.Kind() == reflect.Slice:
+			fields = append(fields, i)
+		}
 	}
-	fLit, ok := sm.Rhs[0].(*FuncLit)
-	if !ok {
-		return
+	refFieldCache.Store(t, fields)
+	return fields
+}
+
+// Returns a node as a value of an interface type. The common node
+// interfaces are converted by the language rather than by reflection,
+// which is much slower.
+func asType(n interface{}, t reflect.Type) reflect.Value {
+	switch t {
+	case exprType:
+		x := n.(Expr)
+		return reflect.ValueOf(&x).Elem()
+	case stmtType:
+		x := n.(Stmt)
+		return reflect.ValueOf(&x).Elem()
+	case declType:
+		x := n.(Decl)
+		return reflect.ValueOf(&x).Elem()
+	case specType:
+		x := n.(Spec)
+		return reflect.ValueOf(&x).Elem()
 	}
-	if fLit.Type.Results != nil {
-		return
+	c := reflect.New(t).Elem()
+	c.Set(reflect.ValueOf(n))
+	return c
+}
+
+// Returns the expression in parentheses, unless it is an operand, so that
+// it keeps its meaning when substituted into another expression.
+func parenthesize(x Expr) Expr {
+	switch x.(type) {
+	case *Ident, *BasicLit, *ParenExpr, *CallExpr, *IndexExpr, *SelectorExpr:
+		return x
 	}
-	return true
+	return &ParenExpr{X: x}
 }
 
-// Test if the loop has an integer loop counter variable with
-// static bounds and simple incrementer
-func isUnwindable(f *ForStmt, fileFilter *regexp.Regexp) (
-	canUnwind bool, startVal, endVal int, identName string) {
-	assign, ok := f.Init.(*AssignStmt)
-	if !ok {
-		return
+// ParseStmts parses a list of statements and returns them without
+// positions, ready to be passed to Replace.
+func ParseStmts(src string) ([]Stmt, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() {\n"+src+"\n}", 0)
+	if err != nil {
+		return nil, err
 	}
-	// Test for single assignment from interger literal
-	if len(assign.Lhs) != 1 || len(assign.Lhs) != 1 || assign.Tok != token.DEFINE {
-		return
+	body := f.Decls[0].(*FuncDecl).Body
+	var m *BlockVisitor // The parsed statements have no source to remember
+	return m.Substitute(body, nil).(*BlockStmt).List, nil
+}
+
+// Returns the statements of a block to be spliced into another block, or
+// the block itself if it declares anything that might collide with the
+// declarations of the other block, or of another copy of the block.
+func spliceable(b *BlockStmt) []Stmt {
+	for _, st := range b.List {
+		switch st := st.(type) {
+		case *DeclStmt:
+			return []Stmt{b}
+		case *AssignStmt:
+			if st.Tok == token.DEFINE {
+				return []Stmt{b}
+			}
+		}
 	}
-	ident, ok := assign.Lhs[0].(*Ident)
-	if !ok || len(fileFilter.FindString(ident.Name)) == 0 {
-		return
+	return b.List
+}
+
+// Writes the source with the edits applied to out.
+// An edit within the source replaced by another edit is dropped.
+func (m *BlockVisitor) applyEdits(out *Buffer) {
+	sort.SliceStable(m.edits, func(i, j int) bool {
+		if m.edits[i].old.Pos() != m.edits[j].old.Pos() {
+			return m.edits[i].old.Pos() < m.edits[j].old.Pos()
+		}
+		return m.edits[i].old.End() > m.edits[j].old.End()
+	})
+	cursor := 0
+	restore := "" // The directive of the source following the last edit
+	src := string(m.src)
+	lines, counted := 0, 0 // The lines of the output counted so far
+	for _, e := range m.edits {
+		start, end := m.tfile.Offset(e.old.Pos()), m.tfile.Offset(e.old.End())
+		if start < cursor {
+			continue
+		}
+		text := m.render(e, indentAt(m.src, start))
+		cursor, restore = writeEdit(out, src, cursor, start, end, text, restore, m.directiveAfter(e.old))
+		lines += Count(out.Bytes()[counted:], []byte("\n"))
+		counted = out.Len()
+		e.place(text, lines)
 	}
-	identName = ident.Name
-	bLit, ok := assign.Rhs[0].(*BasicLit)
-	if !ok || bLit.Kind != token.INT {
-		return
+	writeRestoring(out, src[cursor:], restore)
+}
+
+// Writes the source from the cursor to the start of an edit, followed by
+// the text of the edit, which replaces the source up to its end. Restore
+// is the pending directive of the source before the edit, and after is
+// the directive of the source after it. Returns the new cursor and the
+// pending directive.
+func writeEdit(out io.StringWriter, src string, cursor, start, end int, text, restore, after string) (int, string) {
+	cut, cutEnd := start, end
+	if text == "" {
+		cut, cutEnd = lineOf(src, start, end)
+	} else if strings.HasPrefix(text, "\n") { // The text starts a line of its own
+		lineStart := strings.LastIndexByte(src[:start], '\n') + 1
+		if lineStart >= cursor && strings.TrimSpace(src[lineStart:start]) == "" {
+			cut, text = lineStart, text[1:]
+			// A directive of the line is superseded by one starting the text
+			if prev := strings.LastIndexByte(src[:cut-min(cut, 1)], '\n') + 1; cut > 0 && prev >= cursor &&
+				strings.HasPrefix(src[prev:cut], "//line ") && strings.HasPrefix(text, "//line ") {
+				cut = prev
+			}
+		}
 	}
-	var err error
-	startVal, err = strconv.Atoi(bLit.Value)
-	if err != nil {
-		return
+	// A directive pending at the start of the line is superseded by one
+	// starting the text, or by the directive after a cut line
+	restore = writeRestoring(out, src[cursor:cut], restore)
+	if strings.HasSuffix(restore, "\n") && !strings.HasPrefix(text, "//line ") && cutEnd == end {
+		out.WriteString(restore)
 	}
-	// Test for simple conditional
-	binExrp, ok := f.Cond.(*BinaryExpr)
-	if !ok {
-		return
+	out.WriteString(text)
+	if cutEnd > end && after != "" { // The source after the cut line starts a line
+		return cutEnd, after + "\n"
 	}
-	ident2, ok := binExrp.X.(*Ident)
-	if !ok || ident2.Name != ident.Name {
-		return
+	return cutEnd, after
+}
+
+// Returns the bounds of the line holding the text from start to end, with
+// its newline, if the line holds nothing else, so that text removed without
+// a trace takes its line with it. Otherwise, start and end are returned.
+func lineOf(src string, start, end int) (int, int) {
+	lineStart := strings.LastIndexByte(src[:start], '\n') + 1
+	lineEnd := len(src)
+	if i := strings.IndexByte(src[end:], '\n'); i >= 0 {
+		lineEnd = end + i + 1
 	}
-	blit2, ok := binExrp.Y.(*BasicLit)
-	if !ok || blit2.Kind != token.INT {
-		return
+	if strings.TrimSpace(src[lineStart:start]) != "" || strings.TrimSpace(src[end:lineEnd]) != "" {
+		return start, end
 	}
-	endVal, err = strconv.Atoi(blit2.Value)
-	if err != nil {
-		return
+	return lineStart, lineEnd
+}
+
+// Renders an edit at the given indentation. The nested edits are printed
+// as placeholder statements, which are then replaced by their renderings.
+func (m *BlockVisitor) render(e *edit, indent string) string {
+	var b strings.Builder
+	b.WriteString(indent)            // Trimmed below; it is the indent of the first placeholder
+	keep := e.note != "" && !m.clean // Whether the original is kept in a comment
+	if keep {
+		orig := m.Text(e.old)
+		if !e.old.Pos().IsValid() { // The printed original is not indented
+			orig = strings.ReplaceAll(orig, "\n", "\n"+indent)
+		}
+		b.WriteString(commentOut(orig, e.note))
 	}
-	if binExrp.Op == token.LEQ {
-		endVal++
+	nested := m.placeNested(e)
+	last := "" // The comment ending the line of the last statement
+	for i, st := range e.new {
+		// The lines of the statement are those of the statement it copies
+		pos := m.position(st)
+		if !pos.IsValid() {
+			pos = m.position(e.old)
+		}
+		lead, inner, trail := m.stmtComments(e, st)
+		// A directive needs a line of its own, so a statement that would
+		// continue the line of the original starts a new line instead. The
+		// comments before the statement are written before the directive.
+		if d := m.directiveOf(pos); i > 0 || keep || d != "" {
+			b.WriteString("\n")
+			for _, c := range lead {
+				b.WriteString(indent + c + "\n")
+			}
+			if d != "" {
+				b.WriteString(d + "\n")
+			}
+			b.WriteString(indent)
+		} else {
+			for _, c := range lead {
+				b.WriteString(c + "\n" + indent)
+			}
+		}
+		if i == 0 {
+			e.stmtLine = strings.Count(b.String(), "\n")
+		}
+		var sb strings.Builder
+		var err error
+		if len(inner) > 0 {
+			err = printConfig.Fprint(&sb, m.fset, &printer.CommentedNode{Node: m.positioned(st), Comments: inner})
+		} else {
+			err = printConfig.Fprint(&sb, token.NewFileSet(), st)
+		}
+		if err != nil {
+			m.addError(err)
+		}
+		b.WriteString(strings.ReplaceAll(sb.String(), "\n", "\n"+indent))
+		if i < len(e.new)-1 {
+			if trail != "" {
+				b.WriteString(" " + trail)
+			}
+		} else {
+			last = trail
+		}
 	}
-	// Test incrementer
-	inds, ok := f.Post.(*IncDecStmt)
-	if !ok {
-		return
+	switch {
+	case len(e.new) == 0:
+	case keep:
+		b.WriteString(" /* */")
+	case e.note != "" && !m.lines: // The only trace of a clean edit
+		b.WriteString(" /* " + strings.TrimSpace(e.note+" "+label(e.old)) + " */")
 	}
-	ident3, ok := binExrp.X.(*Ident)
-	if !ok || ident3.Name != ident.Name || inds.Tok != token.INC {
-		return
+	if last != "" { // The trace of the edit is not to be commented out
+		b.WriteString(" " + last)
 	}
-	canUnwind = true
-	return
+	text := b.String()
+	var out strings.Builder
+	cursor := 0
+	restore := ""
+	lines, counted := 0, 0
+	for _, loc := range findPlaceholders(text, len(nested)) {
+		index := loc[2]
+		nestedText := m.render(nested[index], indentAt([]byte(text), loc[0]))
+		cursor, restore = writeEdit(&out, text, cursor, loc[0], loc[1], nestedText, restore,
+			m.directiveAfter(nested[index].old))
+		lines += strings.Count(out.String()[counted:], "\n")
+		counted = out.Len()
+		nested[index].place(nestedText, lines)
+	}
+	writeRestoring(&out, text[cursor:], restore)
+	return out.String()[len(indent):]
 }
 
-// Tests if an ExperStmt has an affirm or deny string
-func canInlineAssert(sm *ExprStmt) (yes bool, callexpr *CallExpr, action string, name string) {
-	callexpr, ok := sm.X.(*CallExpr)
-	if !ok {
+// Returns the comments of the source that go with a new statement of an
+// edit: the comments on the lines before the statement it copies, those
+// within it but not within the statements of the nested edits, and the
+// comment ending its last line. A comment following a token other than an
+// opening brace or a colon is not one before the statement. The comments
+// around a statement copied from within the replaced statement must lie
+// within it too, since the source around it is kept.
+func (m *BlockVisitor) stmtComments(e *edit, st Stmt) (lead []string, inner []*CommentGroup, trail string) {
+	o := m.origin(st)
+	tf := m.fset.File(o.Pos())
+	if tf == nil || m.srcs[tf] == nil {
 		return
 	}
-	tfnc, ok := callexpr.Fun.(*Ident)
-	if !ok {
-		return
+	src := m.srcs[tf]
+	begin, end := token.Pos(tf.Base()), token.Pos(tf.Base()+tf.Size())
+	if old := m.origin(e.old); old.Pos() <= o.Pos() && o.End() <= old.End() {
+		begin, end = old.Pos(), old.End()
 	}
-	name = tfnc.Name
-	if name != "affirm_" && name != "deny_" {
-		return
+	// The text of the source between two positions
+	text := func(from, to token.Pos) string {
+		return string(src[tf.Offset(from):tf.Offset(to)])
 	}
-	action = "return"
-	if len(callexpr.Args) == 2 {
-		switch bl := callexpr.Args[1].(type) {
-		case *BasicLit:
-			if bl.Kind != token.STRING {
-				return
-			}
-			action = string(Trim([]byte(bl.Value), "\"`"))
-			yes = true
-			return
-		default:
-			return
+	first := sort.Search(len(m.comments), func(i int) bool { return m.comments[i].Pos() >= o.Pos() })
+	next := o.Pos()
+	for i := first - 1; i >= 0 && m.comments[i].Pos() >= begin; i-- {
+		c := m.comments[i]
+		if strings.TrimSpace(text(c.End(), next)) != "" {
+			break
+		}
+		lineStart := tf.LineStart(tf.Line(c.Pos()))
+		before := strings.TrimSpace(text(lineStart, c.Pos()))
+		if before != "" && !strings.HasSuffix(before, "{") && !strings.HasSuffix(before, ":") {
+			break
+		}
+		lead = append([]string{c.Text}, lead...)
+		if before != "" {
+			break
 		}
+		next = c.Pos()
 	}
-	if len(callexpr.Args) != 1 { // There needs to be at least one argument
-		return
+	i := first
+	for ; i < len(m.comments) && m.comments[i].End() <= o.End(); i++ {
+		c := m.comments[i]
+		inNested := false
+		for _, n := range e.nested {
+			old := m.origin(n.old)
+			inNested = inNested || old.Pos() <= c.Pos() && c.End() <= old.End()
+		}
+		if !inNested {
+			inner = append(inner, &CommentGroup{List: []*Comment{c}})
+		}
+	}
+	if i < len(m.comments) {
+		c := m.comments[i]
+		if c.Pos() >= o.End() && c.End() <= end && strings.Trim(text(o.End(), c.Pos()), " \t") == "" {
+			lineEnd := end
+			if line := tf.Line(c.End()); line < tf.LineCount() && tf.LineStart(line+1) < lineEnd {
+				lineEnd = tf.LineStart(line + 1)
+			}
+			if strings.TrimSpace(text(c.End(), lineEnd)) == "" {
+				trail = c.Text
+			}
+		}
 	}
-	yes = true
 	return
 }
 
-// Inlines asserts with the keywords 'affirm_' or 'deny_'.
-var assertInline BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
-blockList:
-	for _, statement := range f.List {
-		switch sm := statement.(type) {
-		case *ExprStmt:
-			yes, callexpr, action, name := canInlineAssert(sm)
-			if !yes {
-				continue blockList
-			}
-			pos := (name == "affirm_")
-			// Write up to the for loop to unwind
-			if m.sourceCursor < int(sm.Pos())-1 {
-				m.pbytes.Write(m.sbytes.Bytes()[m.sourceCursor : sm.Pos()-1])
-			}
-			// Comment out the source assertion
-			m.pbytes.WriteString("/* ")
-			m.pbytes.Write(m.sbytes.Bytes()[sm.Pos()-1 : sm.End()-1])
-			m.pbytes.WriteString(" /* inlined assert */\n")
-
-			// Write the assertion
-			m.pbytes.WriteString("if ")
-			switch callexpr.Args[0].(type) {
-			case *BinaryExpr:
-				if pos {
-					m.pbytes.WriteString("(")
-				}
-				m.pbytes.Write(m.sbytes.Bytes()[callexpr.Args[0].Pos()-1 : callexpr.Args[0].End()-1])
-				if pos {
-					m.pbytes.WriteString(")")
-				}
-				var opStr = ""
-				if pos {
-					opStr = "== false "
+// Returns a copy of a new statement with the source positions of the
+// nodes that it copies from within the statement it copies, so that the
+// comments of the statement can be printed in their places. The other
+// nodes, such as the copies of substituted arguments, have no positions.
+func (m *BlockVisitor) positioned(st Stmt) Node {
+	o := m.origin(st)
+	var copyValue func(v reflect.Value) reflect.Value
+	copyValue = func(v reflect.Value) reflect.Value {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return v
+			}
+			c := reflect.New(v.Type().Elem())
+			c.Elem().Set(v.Elem())
+			orig := reflect.Value{}
+			if n, ok := v.Interface().(Node); ok {
+				if on := m.origin(n); on != n && reflect.TypeOf(on) == v.Type() && o.Pos() <= on.Pos() && on.End() <= o.End() {
+					orig = reflect.ValueOf(on).Elem()
 				}
-				m.pbytes.WriteString(opStr + " { " + action + " } /* */")
-			case *Ident:
-				m.pbytes.Write(m.sbytes.Bytes()[callexpr.Args[0].Pos()-1 : callexpr.Args[0].End()-1])
-				var opStr = "=="
-				if !pos {
-					opStr = "!="
+			}
+			for _, i := range refFields(v.Type().Elem()) {
+				f := c.Elem().Field(i)
+				if f.Type() != posType {
+					f.Set(copyValue(f))
+				} else if orig.IsValid() {
+					f.Set(orig.Field(i))
 				}
-				m.pbytes.WriteString(" " + opStr + " nil { " + action + " } /* */ ")
 			}
-			// Advance the source cursor to the end of the assert statement
-			m.sourceCursor = int(sm.End()) - 1
+			return c
+		case reflect.Interface:
+			if v.IsNil() {
+				return v
+			}
+			return asType(copyValue(v.Elem()).Interface(), v.Type())
+		case reflect.Slice:
+			if v.IsNil() {
+				return v
+			}
+			c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(copyValue(v.Index(i)))
+			}
+			return c
 		}
+		return v
 	}
+	return copyValue(reflect.ValueOf(st)).Interface().(Node)
 }
 
-// Unwinds for statements conforming to strict static loop requirements
-var unwindStaticLoop BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
-	for _, statement := range f.List {
-		switch sm := statement.(type) {
-		case *ForStmt:
-			canUnwind, startVal, endVal, identName := isUnwindable(sm, m.funcNameFilter)
-			if canUnwind {
-				subset := make(map[string][]byte, 1)
-				// Write up to the for loop to unwind
-				if m.sourceCursor < int(sm.Pos())-1 {
-					m.pbytes.Write(m.sbytes.Bytes()[m.sourceCursor : sm.Pos()-1])
-				}
-				// comment the for loop
-				m.pbytes.WriteString(" /* ")
-				m.pbytes.Write(m.sbytes.Bytes()[sm.Pos()-1 : sm.Body.Lbrace])
-				m.pbytes.WriteString(" /* unwound */ ")
-				for i := startVal; i < endVal; i++ {
-					subset[identName] = []byte(strconv.Itoa(i))
-					t := int(sm.Body.Pos())
-					pv := &ParamVisitor{subset, t, m}
-					Walk(pv, sm.Body)
-					if pv.templatePosition < int(sm.Body.End())-1 {
-						m.pbytes.Write(TrimRight(
-							m.sbytes.Bytes()[pv.templatePosition:int(sm.Body.End())-2],
-							"\n\t"))
-					}
-				}
-				m.pbytes.WriteString(" /* } */ ")
-				// Advance the source cursor to the end of the for loop
-				m.sourceCursor = int(sm.Body.End()) - 1
-			}
-		}
+// Notes the lines of the rendered text of an edit, which ends on the given
+// line. An edit without new statements has no lines.
+func (e *edit) place(text string, end int) {
+	e.rendered = true
+	e.line0 = end - strings.Count(text, "\n")
+	e.first, e.last = e.line0+e.stmtLine, end
+	if len(e.new) == 0 {
+		e.first = e.last + 1
 	}
 }
 
-var functInline BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
-	var inlines []*AssignStmt
-	for _, statement := range f.List {
-		switch sm := statement.(type) {
-		case *AssignStmt:
-			if isInlineable(sm, m.funcNameFilter) {
-				inlines = append(inlines, sm)
-			}
+// Returns the name of the function called by a statement, the counter of
+// a loop or the variable assigned, or "" if there is none.
+func label(st Stmt) string {
+	switch st := st.(type) {
+	case *ExprStmt:
+		if call, ok := st.X.(*CallExpr); ok {
+			return types.ExprString(call.Fun)
 		}
+	case *ForStmt:
+		if assign, ok := st.Init.(*AssignStmt); ok {
+			return types.ExprString(assign.Lhs[0])
+		}
+	case *AssignStmt:
+		return types.ExprString(st.Lhs[0])
 	}
-	if len(inlines) > 0 {
-		curPlace := m.sourceCursor
-		Walk(&SubVisitor{inlines: inlines, bv: m}, f)
-		if curPlace == m.sourceCursor { // The final cycle can be used to comment out
-			// the template function since the m.sourceCursor has not been advanced
-			for _, infnc := range inlines {
-				m.pbytes.Write(m.sbytes.Bytes()[m.sourceCursor : infnc.Pos()-1])
-				m.pbytes.WriteString(" /* ")
-				m.pbytes.Write(m.sbytes.Bytes()[infnc.Pos()-1 : infnc.End()-1])
-				m.pbytes.WriteString(" /* inlined func */ ")
-				m.sourceCursor = int(infnc.End()) - 1
-			}
+	return ""
+}
+
+// The name of the placeholder statements of nested edits, followed by the
+// index of the edit
+const placeholder = "inlinerEdit"
+
+// Returns the start and end of each of the n placeholders of a text, and
+// the index of its nested edit. The text is scanned rather than matched
+// by a regexp, since each level of nesting scans the text of its edit.
+func findPlaceholders(text string, n int) [][3]int {
+	var locs [][3]int
+	for from := 0; len(locs) < n; {
+		i := strings.Index(text[from:], placeholder)
+		if i < 0 {
+			break
+		}
+		start := from + i
+		end := start + len(placeholder)
+		for end < len(text) && '0' <= text[end] && text[end] <= '9' {
+			end++
+		}
+		from = end
+		index, err := strconv.Atoi(text[start+len(placeholder) : end])
+		if err != nil || start > 0 && isIdentByte(text[start-1]) || end < len(text) && isIdentByte(text[end]) {
+			continue
 		}
+		locs = append(locs, [3]int{start, end, index})
 	}
-	return
+	return locs
 }
 
-func (bv *BlockVisitor) collectTopLevelCandidates(f *File) {
-	for _, d := range f.Decls {
-		switch d := d.(type) {
-		case *FuncDecl:
-			if len(bv.funcNameFilter.FindString(d.Name.Name)) == 0 {
-				break
-			}
-			// Only functions without receivers allowed
-			if d.Recv != nil {
-				return
+func isIdentByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= 0x80
+}
+
+// Replaces the old statements of the nested edits within the new
+// statements of an edit with placeholder statements, and returns the
+// nested edits in the order of their placeholders.
+func (m *BlockVisitor) placeNested(e *edit) []*edit {
+	if len(e.nested) == 0 {
+		return nil
+	}
+	byOld := make(map[Stmt]*edit, len(e.nested))
+	for _, n := range e.nested {
+		byOld[n.old] = n
+	}
+	var nested []*edit
+	place := func(list []Stmt) {
+		for i, st := range list {
+			if n, ok := byOld[st]; ok {
+				list[i] = &ExprStmt{X: NewIdent(placeholder + strconv.Itoa(len(nested)))}
+				m.origins[list[i]] = m.origin(st) // The placeholder has the position of the statement
+				nested = append(nested, n)
 			}
-			// Only functions without results allowed
-			if d.Type.Results != nil {
-				return
+		}
+	}
+	place(e.new)
+	for _, st := range e.new {
+		Inspect(st, func(n Node) bool {
+			if list, ok := stmtList(n); ok {
+				place(list)
 			}
-			bv.inlineFuncs = append(bv.inlineFuncs, d)
+			return true
+		})
+	}
+	return nested
+}
+
+// The configuration used by gofmt
+var printConfig = printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+
+// Returns the source in a comment ending with the note. The source is
+// commented out line by line if it holds the end of a block comment.
+func commentOut(src, note string) string {
+	if strings.Contains(src, "*/") {
+		return "// " + strings.ReplaceAll(src, "\n", "\n// ") + " // " + note
+	}
+	return "/* " + src + " /* " + note + " */"
+}
+
+// Returns the indentation of the line holding the offset.
+func indentAt(src []byte, offset int) string {
+	start := LastIndexByte(src[:offset], '\n') + 1
+	end := start
+	for end < offset && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	return string(src[start:end])
+}
+
+// Type checks the files of a package so that the operators can use type
+// information. Type errors are ignored, since the files hold keywords and
+// calls that can only be resolved once inlined. Expressions that could not
+// be type checked have no type information.
+func typeCheck(fset *token.FileSet, files []*File, importer types.Importer) (*types.Info, *types.Package) {
+	info := &types.Info{Types: make(map[Expr]types.TypeAndValue)}
+	conf := types.Config{Importer: importer, Error: func(error) {}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+	return info, pkg
+}
+
+// Returns the local names of the renamed imports of a file by their paths.
+func importNames(f *File) map[string]string {
+	names := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && spec.Name != nil {
+			names[path] = spec.Name.Name
 		}
 	}
+	return names
+}
+
+// A parsed source file to be inlined
+type source struct {
+	name       string
+	src        []byte
+	lineOffset int    // The number of lines trimmed from the source
+	constraint string // The build constraint of the output, if any
+	header     string // The first line of the output, if any
+	file       *File
 }
 
-func Inline(firstBytes []byte, out io.Writer, fileFilterRegex string) (rErr error) {
-	fileFilter, err := regexp.Compile(fileFilterRegex)
+// Parses a source file into the file set, without the lines that are
+// not copied to the output. A build constraint requiring the tag is
+// trimmed from the source along with the lines before it, and the output
+// is given the rest of the constraint. Other constraints are kept.
+func parseSource(fset *token.FileSet, name string, src []byte, tag string) (*source, error) {
+	// A byte order mark would end up after the generated header
+	src = TrimPrefix(src, []byte("\uFEFF"))
+
+	end, constraint := generateConstraint(src, tag)
+	lineOffset := Count(src[:end], []byte("\n"))
+	src = src[end:]
+	f, err := parser.ParseFile(fset, name, src, parser.AllErrors|parser.ParseComments)
+	if err != nil {
+		return nil, parseErrors(err, lineOffset)
+	}
+	return &source{name: name, src: src, lineOffset: lineOffset, constraint: constraint, file: f}, nil
+}
+
+// Inline inlines the source bytes and writes the result to out.
+func Inline(firstBytes []byte, out io.Writer, opts *Options) (rErr error) {
+	return inlineHeaded(firstBytes, out, opts, "")
+}
+
+// Inlines the source bytes and writes the result, following the header
+// unless it is empty, to out.
+func inlineHeaded(firstBytes []byte, out io.Writer, opts *Options, header string) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	fset := token.NewFileSet()
+	in, err := parseSource(fset, opts.SourceName, firstBytes, opts.tag())
 	if err != nil {
 		return err
 	}
+	in.header = header
+	return inlineSources(fset, []*source{in}, nil, []io.Writer{out}, opts)
+}
 
-	// Trim the '+build generate' directive from the file if present
-	importDecl := regexp.MustCompile(`(^|[\n])\/\/\s+\+build\s+generate\s?[\n]`)
-	imIndex := importDecl.FindIndex(firstBytes)
-	if imIndex != nil {
-		firstBytes = firstBytes[imIndex[1]:]
-	}
-	// Load up a slice of BlockOperator types with the three available block Operators
-	ops := []BlockOperator{functInline, unwindStaticLoop, assertInline}
-	bv := &BlockVisitor{sbytes: *NewBuffer(firstBytes), blockOperators: ops,
-		funcNameFilter: fileFilter}
-	cycles := 0
-	for fired := true; fired; {
-		cycles++
-		fset := token.NewFileSet() // positions are relative to fset apparently ?
-		myAst, err := parser.ParseFile(fset, "", bv.sbytes.Bytes(), parser.AllErrors)
+// Inlines the sources, which belong to the same package as the other
+// files, and writes the result of each source to its writer. The errors of
+// all of the sources are joined, and a source with errors is not written.
+// The top level inlineable functions of all of the files are candidates
+// for inlining.
+func inlineSources(fset *token.FileSet, inputs []*source, others []*source, outs []io.Writer, opts *Options) error {
+	filter := opts.Filter
+	if filter == "" {
+		filter = DefaultFilter
+	}
+	fileFilter, err := regexp.Compile(filter)
+	if err != nil {
+		return err
+	}
+	ops := opts.Operators
+	var opNames []string
+	if ops == nil {
+		regs, err := registered(opts.Enable, opts.Disable)
 		if err != nil {
 			return err
 		}
-		if cycles == 1 {
-			bv.collectTopLevelCandidates(myAst)
+		for _, r := range regs {
+			ops = append(ops, r.op)
+			opNames = append(opNames, r.name)
+		}
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	affirm, deny := opts.Affirm, opts.Deny
+	if affirm == "" {
+		affirm = DefaultAffirm
+	}
+	if deny == "" {
+		deny = DefaultDeny
+	}
+	var files []*File
+	for _, other := range others {
+		files = append(files, other.file)
+	}
+	lineOffsets := make(map[string]int)
+	for _, in := range inputs {
+		files = append(files, in.file)
+		lineOffsets[in.name] = in.lineOffset
+	}
+	shared := BlockVisitor{fset: fset, lines: opts.LineDirectives, clean: opts.Clean, format: opts.Format, blockOperators: ops, opNames: opNames, funcNameFilter: fileFilter,
+		importer: importer.Default(), maxDepth: maxDepth, maxUnwind: opts.MaxUnwind, affirm: affirm, deny: deny, lineOffsets: lineOffsets,
+		imported: make(map[string]*importedPkg), pkgNames: make(map[string]string), srcs: make(map[*token.File][]byte),
+		funcFiles: make(map[*FuncDecl]*File)}
+	for _, f := range files {
+		shared.collectTopLevelCandidates(f)
+	}
+	for _, in := range append(others, inputs...) {
+		shared.srcs[fset.File(in.file.Pos())] = in.src
+		for _, g := range in.file.Comments {
+			shared.comments = append(shared.comments, g.List...)
+		}
+	}
+	sort.Slice(shared.comments, func(i, j int) bool { return shared.comments[i].Pos() < shared.comments[j].Pos() })
+	shared.info, shared.pkg = typeCheck(fset, files, shared.importer)
+	shared.pkgFiles = files
+
+	var errs []error
+
+	for i, in := range inputs {
+		bv := shared
+		bv.src = in.src
+		bv.tfile = fset.File(in.file.Pos())
+		bv.importNames = importNames(in.file)
+		bv.file = in.file
+		bv.srcDir = filepath.Dir(in.name)
+		bv.inlinedFrom = make(map[string]bool)
+		bv.replaced = make(map[Stmt]bool)
+		bv.origins = make(map[Node]Node)
+		if opts.Explain != nil || opts.Report != nil {
+			bv.explained = make(map[Node]*explanation)
+			bv.explainDecls(in.file)
+		}
+		if opts.AssertStats {
+			bv.statsName = statsIdent(in.name)
+			bv.statsIndex = make(map[string]int)
+			bv.countAsserts(in.file)
+		}
+		Walk(&bv, in.file)
+		if opts.Explain != nil {
+			if err := bv.writeExplanations(opts.Explain); err != nil {
+				return err
+			}
+		}
+		if len(bv.edits) > 0 && len(bv.errs) == 0 {
+			var processed Buffer
+			bv.applyEdits(&processed)
+			bv.src = processed.Bytes()
+		}
+		if len(bv.errs) > 0 { // The other sources are still inlined for their errors
+			errs = append(errs, bv.errs...)
+			continue
+		}
+		spliced := bv.src // The source with the edits, before the imports are fixed
+		bv.src = bv.headDirective(bv.src)
+		if bv.src, err = bv.fixImports(bv.src); err != nil {
+			return err
+		}
+		var out Buffer
+		if in.constraint != "" {
+			out.WriteString("//go:build " + in.constraint + "\n")
+		}
+		if len(bv.statsPos) > 0 { // Only files with assertions have counters
+			if err = bv.writeAssertStats(bv.src, &out); err != nil {
+				return err
+			}
+		} else {
+			out.Write(bv.src)
+		}
+		inlined := out.Bytes()
+		if opts.Format { // The header is left out, lest it joins a leading directive
+			formatted, err := format.Source(inlined)
+			if err != nil {
+				return err
+			}
+			if HasPrefix(inlined, []byte("\n")) { // The line separating the header is kept
+				formatted = append([]byte("\n"), formatted...)
+			}
+			inlined = formatted
+		}
+		if opts.Diff != nil {
+			if err = bv.writeDiff(opts.Diff, in.name, in.src, inlined); err != nil {
+				return err
+			}
 		}
-		Walk(bv, myAst)
-		//Print(fset, myAst)
-		//os.Exit(0)
-		if bv.sourceCursor < len(bv.sbytes.Bytes()) {
-			bv.pbytes.Write(bv.sbytes.Bytes()[bv.sourceCursor:])
+		result := append([]byte(in.header), inlined...)
+		if opts.Report != nil {
+			if err = bv.writeReport(opts.Report, in.name, spliced, result); err != nil {
+				return err
+			}
 		}
-		fired = bv.sourceCursor != 0 // Was anything done?
-		if fired {
-			bv.sbytes, bv.pbytes = bv.pbytes, bv.sbytes // Swap source and processed byte bufers
-			bv.pbytes.Reset()                           // Clear the processed pad
-			bv.sourceCursor = 0
+		if _, err = outs[i].Write(result); err != nil {
+			return err
 		}
 	}
-	out.Write(bv.sbytes.Bytes())
-	return
+	return errors.Join(errs...)
 }
 
-func InlineFile(fileName string, w io.Writer, fileFilter string) (rErr error) {
-	firstBytes, rErr := ioutil.ReadFile(fileName)
-	if rErr != nil {
-		return
-	}
-	_, rErr = w.Write([]byte("// This file is generated by inliner. DO NOT EDIT.\n"))
-	if rErr != nil {
-		return
-	}
-	_, rErr = w.Write([]byte("// Source file: " + fileName + "\n"))
-	if rErr != nil {
-		return
-	}
-	return Inline(firstBytes, w, fileFilter)
+// The start of the first line of the files written by inliner, which
+// follows the convention of generated Go files, and the first line of the
+// files written by earlier versions.
+const (
+	generatedHeader = "// Code generated by inliner"
+	legacyHeader    = "// This file is generated by inliner. DO NOT EDIT.\n"
+)
+
+// Returns the header of an inlined file, which names its source file.
+func header(fileName string) string {
+	return generatedHeader + " from " + filepath.Base(fileName) + ". DO NOT EDIT.\n"
 }
 
-func main() {
-	var outputFile, inputFile, fileFilter string
-	help := false
-	flag.BoolVar(&help, "help", false, "Print arguments")
-	flag.StringVar(&outputFile, "out", "", "Name of output file")
-	flag.StringVar(&inputFile, "in", "", "Name of input file")
-	flag.StringVar(&fileFilter, "filter", "_$", "Regular expression to filter inlineable names.")
-	flag.Parse()
-	if len(outputFile) == 0 || len(inputFile) == 0 {
-		fmt.Println("Illegal command arguments")
-		flag.CommandLine.PrintDefaults()
-		return
+// Returns the header of an inlined file written to the named output, which
+// names its source file by its slash separated path from the directory of
+// the output, so that the source of an output kept in another directory
+// can be found.
+func outputHeader(fileName, outName string) string {
+	rel, err := filepath.Rel(filepath.Dir(outName), fileName)
+	if err != nil {
+		return header(fileName)
 	}
-	if help {
-		fmt.Println("inliner utility for Go language intended for use with go generate")
-		flag.CommandLine.PrintDefaults()
+	return generatedHeader + " from " + filepath.ToSlash(rel) + ". DO NOT EDIT.\n"
+}
+
+// Tests if a file was written by inliner.
+func isGenerated(src []byte) bool {
+	return HasPrefix(src, []byte(generatedHeader)) || HasPrefix(src, []byte(legacyHeader))
+}
+
+// InlineFile inlines the named file and writes the result, headed by a
+// "Code generated ... DO NOT EDIT." comment naming the source file, to w.
+// If w has a Name method, like an *os.File, the source file is named by
+// its path from the directory of the output file. The file name is used as
+// the SourceName of the options.
+func InlineFile(fileName string, w io.Writer, opts *Options) (rErr error) {
+	firstBytes, rErr := ioutil.ReadFile(fileName)
+	if rErr != nil {
 		return
 	}
-	w, err := os.Create(outputFile)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "File ", outputFile, " Outfile create error ", err)
-		return
+	fileOpts := Options{}
+	if opts != nil {
+		fileOpts = *opts
 	}
-	defer w.Close()
-	err = InlineFile(inputFile, w, fileFilter)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "inline error", err)
+	fileOpts.SourceName = fileName
+	head := header(fileName)
+	if out, ok := w.(interface{ Name() string }); ok {
+		head = outputHeader(fileName, out.Name())
 	}
+	return inlineHeaded(firstBytes, w, &fileOpts, head)
 }
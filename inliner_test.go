@@ -0,0 +1,1066 @@
+package inliner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	. "go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/srwiley/Inliner/internal/reparse"
+)
+
+const filterSource = `package p
+
+func sum(xs []float64) (s float64) {
+	add := func(x float64) {
+		s += x
+	}
+	for i := 0; i < 2; i++ {
+		add(xs[i])
+	}
+	return
+}
+`
+
+func TestInlineFilter(t *testing.T) {
+	var out bytes.Buffer
+	if err := Inline([]byte(filterSource), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != filterSource {
+		t.Errorf("names not matching the default filter were inlined:\n%s", out.String())
+	}
+	out.Reset()
+	if err := Inline([]byte(filterSource), &out, &Options{Filter: "^(add|i)$"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"s += xs[0]", "s += xs[1]", "/* inlined func */"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInlineBOM(t *testing.T) {
+	var out bytes.Buffer
+	src := "\uFEFF" + filterSource
+	if err := Inline([]byte(src), &out, &Options{Filter: "^(add|i)$"}); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out.Bytes(), []byte("\uFEFF")) || !strings.Contains(out.String(), "s += xs[1]") {
+		t.Errorf("source with a byte order mark not inlined:\n%s", out.String())
+	}
+}
+
+func TestBuildConstraints(t *testing.T) {
+	for _, tt := range []struct{ src, want string }{
+		{"//go:build generate\n\npackage p\n", "\npackage p\n"},
+		{"// +build generate\n\npackage p\n", "\npackage p\n"},
+		{"//go:build generate && amd64\n// +build generate,amd64\n\npackage p\n", "//go:build amd64\n\npackage p\n"},
+		{"//go:build (generate || test) && !windows\n\npackage p\n", "//go:build !windows\n\npackage p\n"},
+		{"// Copyright\n\n//go:build linux || generate\n\npackage p\n", "\npackage p\n"},
+		{"//go:build linux\n\npackage p\n", "//go:build linux\n\npackage p\n"},
+		{"//go:build !generate\n\npackage p\n", "//go:build !generate\n\npackage p\n"},
+	} {
+		var out bytes.Buffer
+		if err := Inline([]byte(tt.src), &out, nil); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.want)
+		}
+	}
+	name := filepath.Join(t.TempDir(), "k.go")
+	if err := ioutil.WriteFile(name, []byte("//go:build generate && amd64\n\npackage p\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := InlineFile(name, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by inliner from k.go. DO NOT EDIT.\n//go:build amd64\n\npackage p\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", out.Bytes(), parser.ParseComments)
+	if err != nil || !IsGenerated(f) {
+		t.Errorf("output not recognized as generated: %v", err)
+	}
+}
+
+// Replaces calls to 'todo_' with a panic.
+var todoOperator BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
+	for _, statement := range f.List {
+		sm, ok := statement.(*ExprStmt)
+		if !ok {
+			continue
+		}
+		callexpr, ok := sm.X.(*CallExpr)
+		if !ok {
+			continue
+		}
+		if fnc, ok := callexpr.Fun.(*Ident); !ok || fnc.Name != "todo_" {
+			continue
+		}
+		stmts, err := ParseStmts(`panic("not implemented")`)
+		if err != nil {
+			m.Errorf(sm, "%v", err)
+			return
+		}
+		m.Replace(sm, "", stmts...)
+	}
+}
+
+func TestCustomOperator(t *testing.T) {
+	src := "package p\n\nfunc f() {\n\ttodo_()\n}\n"
+	var out bytes.Buffer
+	ops := []BlockOperator{todoOperator}
+	if err := Inline([]byte(src), &out, &Options{Operators: ops}); err != nil {
+		t.Fatal(err)
+	}
+	want := "package p\n\nfunc f() {\n\tpanic(\"not implemented\")\n}\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	Register("todo", FunctInlinePriority-1, todoOperator)
+	t.Cleanup(func() { unregister("todo") }) // The other tests use the default operators
+	names := strings.Join(Registered(), ",")
+	if names != "todo,functInline,unwindStaticLoop,contractInline,assertInline" {
+		t.Errorf("registered operators out of order: %s", names)
+	}
+	src := "package p\n\nfunc f(x int) {\n\ttodo_()\n\taffirm_(x > 0)\n}\n"
+	var out bytes.Buffer
+	if err := Inline([]byte(src), &out, &Options{Disable: []string{"assertInline"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "panic(") || strings.Contains(out.String(), "inlined assert") {
+		t.Errorf("operators not selected as expected:\n%s", out.String())
+	}
+	out.Reset()
+	if err := Inline([]byte(src), &out, &Options{Enable: []string{"assertInline"}}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "panic(") || !strings.Contains(out.String(), "inlined assert") {
+		t.Errorf("operators not selected as expected:\n%s", out.String())
+	}
+	if _, err := Operators(nil, []string{"noSuchOperator"}); err == nil {
+		t.Error("unknown operator not reported")
+	}
+}
+
+// Returns the source of a large kernel file with n functions, one in ten
+// of which inlines a chain of depth nested local functions within unwound
+// loops.
+func kernelSource(n, depth int) []byte {
+	var b bytes.Buffer
+	b.WriteString("package p\n\nfunc mad_(s *float64, x, y float64) {\n\t*s += x * y\n}\n")
+	for i := 0; i < n; i++ {
+		if i%10 != 0 {
+			fmt.Fprintf(&b, `
+func kernel%d(xs, ys []float64) (s float64) {
+	for i := range xs {
+		s += xs[i] * ys[i]
+		if s > 1e9 {
+			s = 0
+		}
+	}
+	return
+}
+`, i)
+			continue
+		}
+		fmt.Fprintf(&b, "\nfunc kernel%d(xs, ys []float64) (s float64) {\n", i)
+		b.WriteString("\tlevel1_ := func(i int) {\n\t\tmad_(&s, xs[i], ys[i])\n\t}\n")
+		for level := 2; level <= depth; level++ {
+			fmt.Fprintf(&b, "\tlevel%d_ := func(i int) {\n\t\tlevel%d_(i)\n\t}\n", level, level-1)
+		}
+		fmt.Fprintf(&b, `	quad_ := func(i int) {
+		for j_ := 0; j_ < 4; j_++ {
+			level%d_(i*4 + j_)
+		}
+	}
+	for k_ := 0; k_ < 4; k_++ {
+		quad_(k_)
+	}
+	return
+}
+`, depth)
+	}
+	return b.Bytes()
+}
+
+func TestInlineNested(t *testing.T) {
+	var out bytes.Buffer
+	if err := Inline(kernelSource(1, 3), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "*(&s) += xs[(3*4+3)]"); n != 1 {
+		t.Errorf("innermost expansion found %d times:\n%s", n, out.String())
+	}
+	if n := strings.Count(out.String(), "*(&s) += "); n != 16 {
+		t.Errorf("got %d expansions, want 16:\n%s", n, out.String())
+	}
+}
+
+func TestInlineCaseClause(t *testing.T) {
+	src := `package p
+
+func f(x int) (s int) {
+	add_ := func(y int) {
+		s += y
+	}
+	switch x {
+	case 0:
+		add_(1)
+	default:
+		add_(x)
+	}
+	return
+}
+`
+	var out bytes.Buffer
+	if err := Inline([]byte(src), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"s += 1 /* */", "s += x /* */"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInlineComments(t *testing.T) {
+	src := `package p
+
+func f(xs []int) (s int) {
+	add_ := func(x int) { // accumulate x
+		s += x // the sum
+	}
+	for i_ := 0; i_ < 2; i_++ {
+		// add one
+		add_(xs[i_])
+		if s > 3 {
+			s-- /* less */
+		}
+	}
+	return
+}
+`
+	for _, clean := range []bool{false, true} {
+		var out bytes.Buffer
+		if err := Inline([]byte(src), &out, &Options{Clean: clean}); err != nil {
+			t.Fatal(err)
+		}
+		got := out.String()
+		if _, err := parser.ParseFile(token.NewFileSet(), "p_inlined.go", got, 0); err != nil {
+			t.Fatalf("%v:\n%s", err, got)
+		}
+		for _, want := range []string{"\n\t// add one\n", "// accumulate x\n\ts += xs[", "*/ // the sum\n", "\n\t\ts-- /* less */\n"} {
+			if n := strings.Count(got, want); n != 2 {
+				t.Errorf("clean %v: found %q %d times, want 2:\n%s", clean, want, n, got)
+			}
+		}
+	}
+}
+
+func TestRecursiveCandidates(t *testing.T) {
+	for _, tt := range []struct{ src, cycle string }{
+		{`package p
+
+func a_(x int) {
+	b_(x)
+}
+
+func b_(x int) {
+	a_(x - 1)
+}
+
+func f() {
+	a_(3)
+}
+`, "4:2: recursive inline candidates: b_ -> a_ -> b_"},
+		{`package p
+
+func f() {
+	c_ := func() {
+		c_()
+	}
+	c_()
+}
+`, "c_ -> c_"},
+	} {
+		var out bytes.Buffer
+		err := Inline([]byte(tt.src), &out, nil)
+		if err == nil || !strings.Contains(err.Error(), tt.cycle) {
+			t.Errorf("got error %v, want the cycle %s", err, tt.cycle)
+		}
+	}
+}
+
+func TestInlineErrors(t *testing.T) {
+	src := `// +build generate
+
+package p
+
+func f() {
+	c_ := func() {
+		c_()
+	}
+	c_()
+}
+
+func g() {
+	d_ := func() {
+		d_()
+	}
+	d_()
+}
+`
+	var out bytes.Buffer
+	err := Inline([]byte(src), &out, &Options{SourceName: "p.go"})
+	var inlineErr *InlineError
+	if !errors.As(err, &inlineErr) {
+		t.Fatalf("got error %v, want an *InlineError", err)
+	}
+	if inlineErr.Pos.Filename != "p.go" || inlineErr.Pos.Line != 7 || inlineErr.Operator != "functInline" {
+		t.Errorf("got the error %+v, want one of functInline at p.go:7", inlineErr)
+	}
+	want := "p.go:7:3: recursive inline candidates: c_ -> c_ (functInline)\n" +
+		"p.go:14:3: recursive inline candidates: d_ -> d_ (functInline)"
+	if err.Error() != want {
+		t.Errorf("got the errors:\n%v\nwant:\n%s", err, want)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output written for a source with errors:\n%s", out.String())
+	}
+	_, err = parseSource(token.NewFileSet(), "q.go", []byte("// +build generate\n\npackage p\n\nfunc f() {\n"), DefaultTag)
+	if !errors.As(err, &inlineErr) || inlineErr.Pos.Line != 5 {
+		t.Errorf("got the parse error %v, want an *InlineError on line 5", err)
+	}
+}
+
+func TestPostconditionReturn(t *testing.T) {
+	src := `package p
+
+func f(x int) (r int) {
+	post_(r > 0, "return -1")
+	post_(r < 10, "r = func() int { return 9 }()")
+	r = x
+	return
+}
+`
+	var out bytes.Buffer
+	err := Inline([]byte(src), &out, &Options{SourceName: "p.go"})
+	var inlineErr *InlineError
+	if !errors.As(err, &inlineErr) || inlineErr.Pos.Line != 4 || inlineErr.Operator != "contractInline" {
+		t.Fatalf("got error %v, want one of contractInline at p.go:4", err)
+	}
+	if !strings.Contains(err.Error(), "cannot return") || strings.Contains(err.Error(), "p.go:5") {
+		t.Errorf("got the errors:\n%v\nwant one of the postcondition returning", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	src := `package p
+
+func f(n int) (s int) {
+	pre_(n > 0)
+	add_ := func(x int) {
+		s += x
+	}
+	twice_ := func(x int) int {
+		return 2 * x
+	}
+	unused_ := func() {}
+	for i_ := 0; i_ < 2; i_++ {
+		add_(i_)
+	}
+	for j_ := 0; j_ < n; j_++ {
+		add_(j_, 1)
+	}
+	affirm_()
+	post_(s >= 0)
+	return
+}
+`
+	var out, explain bytes.Buffer
+	if err := Inline([]byte(src), &out, &Options{SourceName: "p.go", Explain: &explain}); err != nil {
+		t.Fatal(err)
+	}
+	want := `p.go:4:2: contract pre_: inlined 1 time
+p.go:5:2: func add_: inlined 2 times
+p.go:8:2: func twice_: rejected: it has results
+p.go:11:2: func unused_: not called
+p.go:12:2: loop i_: unwound 1 time
+p.go:15:2: loop j_: rejected: the bound is not an integer literal
+p.go:16:3: call add_: rejected: it passes 2 arguments for 1 parameters
+p.go:18:2: assert affirm_: rejected: it has no condition
+p.go:19:2: contract post_: rejected: it is not at the top of a function declaration
+`
+	if explain.String() != want {
+		t.Errorf("got the explanation:\n%s\nwant:\n%s", explain.String(), want)
+	}
+}
+
+func TestLineDirectives(t *testing.T) {
+	src := `// +build generate
+
+package p
+
+func f(xs []int) (s int) {
+	add_ := func(x int) {
+		s += x
+	}
+	for i_ := 0; i_ < 2; i_++ {
+		add_(xs[i_])
+	}
+	s *= 2
+	return
+}
+`
+	var out bytes.Buffer
+	opts := &Options{SourceName: "p.go", LineDirectives: true}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	// The output is parsed with its directives, like the compiler does
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p_inlined.go", out.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("%v:\n%s", err, out.String())
+	}
+	lines := make(map[string][]string)
+	Inspect(f, func(n Node) bool {
+		if st, ok := n.(*AssignStmt); ok {
+			pos := fset.Position(st.Pos())
+			text := types.ExprString(st.Lhs[0]) + st.Tok.String() + types.ExprString(st.Rhs[0])
+			lines[text] = append(lines[text], fmt.Sprintf("%s:%d", pos.Filename, pos.Line))
+		}
+		return true
+	})
+	for text, want := range map[string]string{
+		"s+=xs[0]": "p.go:7", // The line of the inlined function's body
+		"s+=xs[1]": "p.go:7",
+		"s*=2":     "p.go:12", // The line following the unwound loop
+	} {
+		if got := strings.Join(lines[text], ","); got != want {
+			t.Errorf("%s is at %s, want %s:\n%s", text, got, want, out.String())
+		}
+	}
+}
+
+func TestCleanOutput(t *testing.T) {
+	src := `// +build generate
+
+package p
+
+func f(xs []int) (s int) {
+	add_ := func(x int) {
+		s += x
+	}
+	for i_ := 0; i_ < 2; i_++ {
+		add_(xs[i_])
+	}
+	return
+}
+`
+	for _, lines := range []bool{false, true} {
+		var out bytes.Buffer
+		opts := &Options{SourceName: "p.go", Clean: true, LineDirectives: lines}
+		if err := Inline([]byte(src), &out, opts); err != nil {
+			t.Fatal(err)
+		}
+		got := out.String()
+		if _, err := parser.ParseFile(token.NewFileSet(), "p_inlined.go", got, 0); err != nil {
+			t.Fatalf("%v:\n%s", err, got)
+		}
+		if strings.Contains(got, "add_ :=") || strings.Contains(got, "for i_") {
+			t.Errorf("replaced source is kept:\n%s", got)
+		}
+		if n := strings.Count(got, "s += xs["); n != 2 {
+			t.Errorf("found %d inlined statements, want 2:\n%s", n, got)
+		}
+		// The comments only stand in for directives
+		if hasComment := strings.Contains(got, "/* inlined add_ */"); hasComment == lines {
+			t.Errorf("lines %v: found comment %v:\n%s", lines, hasComment, got)
+		}
+	}
+}
+
+func TestFormatOutput(t *testing.T) {
+	src := `//go:build generate
+
+package p
+
+import (
+	"fmt"
+	"strings"
+	_ "unsafe"
+)
+
+func f(xs []int) (s int) {
+	add_ := func(x int) {
+		s   +=   x
+	}
+	add_(xs[0])
+	fmt.Println(s, math.MaxInt)
+	return
+}
+`
+	var out bytes.Buffer
+	opts := &Options{SourceName: "p.go", Clean: true, Format: true}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	got := out.Bytes()
+	formatted, err := format.Source(got)
+	if err != nil {
+		t.Fatalf("%v:\n%s", err, got)
+	}
+	if !bytes.Equal(bytes.TrimLeft(got, "\n"), formatted) {
+		t.Errorf("output is not formatted:\n%s", got)
+	}
+	text := string(got)
+	if strings.Contains(text, `"strings"`) {
+		t.Errorf("unused import is kept:\n%s", text)
+	}
+	if !strings.Contains(text, `"fmt"`) || !strings.Contains(text, `_ "unsafe"`) {
+		t.Errorf("used or blank import is removed:\n%s", text)
+	}
+	if !strings.Contains(text, "import \"math\"\n") {
+		t.Errorf("missing import is not added:\n%s", text)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nk\nl"
+	// The changes are separated by more than twice the context
+	want := `--- old.go
++++ new.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -7,5 +7,5 @@
+ g
+ h
+ i
+-j
+ k
++l
+\ No newline at end of file
+`
+	if got := string(Diff("old.go", "new.go", []byte(old), []byte(new))); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := Diff("old.go", "new.go", []byte(old), []byte(old)); got != nil {
+		t.Errorf("got a diff of equal texts:\n%s", got)
+	}
+}
+
+func TestDiffOption(t *testing.T) {
+	src := `//go:build generate
+
+package p
+
+func f(xs []int) (s int) {
+	add_ := func(x int) {
+		s += x
+	}
+	add_(xs[0])
+	s *= 2
+	s *= 3
+	s *= 4
+	s *= 5
+	s *= 6
+	s *= 7
+	s *= 8
+	for i_ := 0; i_ < 2; i_++ {
+		s += i_
+	}
+	return
+}
+`
+	var out, diff bytes.Buffer
+	opts := &Options{SourceName: "p.go", Diff: &diff}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	var headers []string
+	for _, line := range strings.Split(diff.String(), "\n") {
+		if strings.HasPrefix(line, "@@") {
+			headers = append(headers, line)
+		}
+	}
+	// The lines of the diff are those of the source without its constraint
+	want := []string{"@@ -2,10 +2,11 @@ functInline", "@@ -13,8 +14,10 @@ unwindStaticLoop"}
+	if strings.Join(headers, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got hunks %q, want %q:\n%s", headers, want, diff.String())
+	}
+	if !strings.HasPrefix(diff.String(), "--- p.go\n+++ p.go (inlined)\n") {
+		t.Errorf("diff is not of p.go:\n%s", diff.String())
+	}
+}
+
+func TestReport(t *testing.T) {
+	src := `//go:build generate
+
+package p
+
+func f(xs []int) (s int) {
+	add_ := func(x int) {
+		s += x
+	}
+	add_(xs[0])
+	for i_ := 0; i_ < 2; i_++ {
+		s += i_
+	}
+	return
+}
+`
+	var out, report bytes.Buffer
+	opts := &Options{SourceName: "p.go", Report: &report}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	var r Report
+	if err := json.Unmarshal(report.Bytes(), &r); err != nil {
+		t.Fatalf("%v:\n%s", err, report.String())
+	}
+	if r.Source != "p.go" {
+		t.Errorf("got source %q, want p.go", r.Source)
+	}
+	lines := strings.Split(out.String(), "\n")
+	var got []string
+	var describe func(ts []Transformation, depth int)
+	describe = func(ts []Transformation, depth int) {
+		for _, tr := range ts {
+			d := fmt.Sprintf("%*s%s %s %d", 2*depth, "", tr.Operator, tr.Name, tr.Source.StartLine)
+			if tr.Output != nil {
+				d += ":" + strings.TrimSpace(lines[tr.Output.StartLine-1]) + "|" + strings.TrimSpace(lines[tr.Output.EndLine-1])
+			}
+			got = append(got, d)
+			describe(tr.Nested, depth+1)
+		}
+	}
+	describe(r.Transformations, 0)
+	// The lines of the source are counted with its constraint
+	want := []string{
+		"functInline add_ 6",
+		"functInline add_ 9:s += xs[0] /* */|s += xs[0] /* */",
+		"unwindStaticLoop i_ 10:s += 0|s += 1 /* */",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got transformations\n%s\nwant\n%s\noutput:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"), out.String())
+	}
+	var candidates []string
+	for _, c := range r.Candidates {
+		candidates = append(candidates, fmt.Sprintf("%d %s %s %d", c.Line, c.Kind, c.Name, c.Count))
+	}
+	want = []string{"6 func add_ 1", "10 loop i_ 1"}
+	if strings.Join(candidates, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got candidates %q, want %q", candidates, want)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	src := `package p
+
+func f() {
+	for i_ := 0; i_ < 2; i_++ {
+		for j_ := 0; j_ < 2; j_++ {
+			for k_ := 0; k_ < 2; k_++ {
+				println(i_, j_, k_)
+			}
+		}
+	}
+}
+`
+	var out bytes.Buffer
+	if err := Inline([]byte(src), &out, &Options{MaxDepth: 3}); err != nil {
+		t.Fatal(err)
+	}
+	err := Inline([]byte(src), &out, &Options{MaxDepth: 2})
+	if err == nil || !strings.Contains(err.Error(), "nested more than 2 deep") {
+		t.Errorf("got error %v, want the nesting limit", err)
+	}
+}
+
+func TestMaxUnwind(t *testing.T) {
+	src := `package p
+
+func f() {
+	for i_ := 0; i_ < 2; i_++ {
+		for j_ := 0; j_ <= 3; j_++ {
+			println(i_, j_)
+		}
+	}
+}
+`
+	var out, explain bytes.Buffer
+	opts := &Options{SourceName: "p.go", MaxUnwind: 3, Explain: &explain}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	// The outer loop is unwound, and the copies of the inner one are kept
+	if got := strings.Count(out.String(), "for j_ := 0; j_ <= 3; j_++ {"); got != 3 {
+		t.Errorf("found %d inner loops, want the original and 2 copies:\n%s", got, out.String())
+	}
+	if want := "p.go:5:3: loop j_: rejected: it has 4 iterations, more than the limit of 3\n"; !strings.Contains(explain.String(), want) {
+		t.Errorf("got explanations:\n%s\nwant:\n%s", explain.String(), want)
+	}
+}
+
+func TestAssertKeywords(t *testing.T) {
+	src := `package p
+
+func f(x int) {
+	check_(x > 0, "panic($index)")
+	refute_(x > 9, "panic($index)")
+	affirm_(x > 1)
+}
+`
+	var out bytes.Buffer
+	opts := &Options{Affirm: "check_", Deny: "refute_"}
+	if err := Inline([]byte(src), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	for _, want := range []string{"if (x > 0) == false {", "if x > 9 {", "\taffirm_(x > 1)\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestInlineImported(t *testing.T) {
+	src := `package p
+
+import vm "github.com/srwiley/Inliner/testfiles/vecmath"
+
+func f(v vm.Vec) (n float64) {
+	vm.Norm_(&n, v)
+	vm.Count_()
+	return
+}
+`
+	var out bytes.Buffer
+	if err := Inline([]byte(src), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"import \"math\"", "*(&n) = math.Sqrt(vm.Dot(v, v))", "\tvm.Count_()"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInlineImportedUnexported(t *testing.T) {
+	src := `package p
+
+import vm "github.com/srwiley/Inliner/testfiles/vecmath"
+
+func f(c *vm.Counter) {
+	vm.Inc_(c)
+	vm.Reset_(c)
+}
+`
+	var out, explain bytes.Buffer
+	if err := Inline([]byte(src), &out, &Options{SourceName: "p.go", Explain: &explain}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"\tvm.Inc_(c)\n", "\tvm.Reset_(c)\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+	for _, want := range []string{"the field n, which is not exported", "the method reset, which is not exported"} {
+		if !strings.Contains(explain.String(), want) {
+			t.Errorf("explanation does not contain %q:\n%s", want, explain.String())
+		}
+	}
+}
+
+// A Buffer that can be returned by the create function of InlinePackage
+type closeBuffer struct{ bytes.Buffer }
+
+func (b *closeBuffer) Close() error { return nil }
+
+func TestInlinePackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"util.go": "package p\n\nimport \"math\"\n\nfunc scale_(s *float64, k float64) {\n\t*s *= math.Abs(k)\n}\n",
+		// The inlined file imports the package of a renamed import
+		"root.go": "package p\n\nimport m \"math\"\n\nfunc root_(s *float64) {\n\t*s = m.Sqrt(*s)\n}\n",
+		// The renamed import that the kernel lacks is added as another file imports it
+		"kernel.go": "// +build generate\n\npackage p\n\nfunc kernel(s float64) float64 {\n" +
+			"\tscale_(&s, 2)\n\troot_(&s)\n\treturn s + m.Pi\n}\n",
+		// A previous result, which does not hold candidates of its own
+		"kernel_inlined.go": generatedHeader + " from kernel.go. DO NOT EDIT.\n\npackage p\n\nfunc kernel(s float64) float64 {\n\treturn s\n}\n",
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outs := make(map[string]*closeBuffer)
+	err := InlinePackage(dir, func(name string) (io.WriteCloser, error) {
+		outs[name] = new(closeBuffer)
+		return outs[name], nil
+	}, &Options{Format: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outs) != 1 {
+		t.Fatalf("got %d outputs, want 1", len(outs))
+	}
+	out := outs[filepath.Join(dir, "kernel.go")]
+	if out == nil || !strings.Contains(out.String(), "*(&s) *= math.Abs(2)") || !strings.Contains(out.String(), "*(&s) = math.Sqrt(*(&s))") {
+		t.Fatalf("candidates of other files not inlined: %v", outs)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", out.String(), parser.ImportsOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imports []string
+	for _, spec := range f.Imports {
+		if spec.Name != nil {
+			imports = append(imports, spec.Name.Name+" "+spec.Path.Value)
+		} else {
+			imports = append(imports, spec.Path.Value)
+		}
+	}
+	if got, want := strings.Join(imports, ", "), `"math", m "math"`; got != want {
+		t.Errorf("got imports %s, want %s:\n%s", got, want, out)
+	}
+}
+
+// The files of other platforms are inlined too, like single files are.
+func TestInlinePackagePlatform(t *testing.T) {
+	root := t.TempDir()
+	goos := "windows"
+	if runtime.GOOS == goos {
+		goos = "linux"
+	}
+	src := "//go:build generate && " + goos + "\n\npackage w\n\nfunc kernel(s float64) float64 {\n" +
+		"\tfor i_ := 0; i_ < 2; i_++ {\n\t\ts += 1\n\t}\n\treturn s\n}\n"
+	name := filepath.Join(root, "w", "kernel.go")
+	if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(name, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	outs := make(map[string]*closeBuffer)
+	err := InlineTree(root, func(name string) (io.WriteCloser, error) {
+		outs[name] = new(closeBuffer)
+		return outs[name], nil
+	}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := outs[name]
+	if out == nil || !strings.Contains(out.String(), "//go:build "+goos+"\n") || !strings.Contains(out.String(), "s += 1") {
+		t.Errorf("file of %s not inlined: %v", goos, outs)
+	}
+}
+
+func TestInlineTree(t *testing.T) {
+	root := t.TempDir()
+	kernel := "//go:build inline\n\npackage %s\n\nfunc kernel(s float64) float64 {\n" +
+		"\tfor i_ := 0; i_ < 2; i_++ {\n\t\ts += 1\n\t}\n\treturn s\n}\n"
+	files := map[string]string{
+		"p/kernel.go":        fmt.Sprintf(kernel, "p"),
+		"p/q/kernel.go":      fmt.Sprintf(kernel, "q"),
+		"p/plain.go":         "package p\n",
+		"p/gone_inlined.go":  generatedHeader + " from gone.go. DO NOT EDIT.\n\npackage p\n",
+		"testdata/kernel.go": fmt.Sprintf(kernel, "testdata"),
+		"_skip/kernel.go":    fmt.Sprintf(kernel, "skip"),
+	}
+	for name, src := range files {
+		name = filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var written, removed []string
+	err := InlineTree(root, func(name string) (io.WriteCloser, error) {
+		written = append(written, name)
+		return new(closeBuffer), nil
+	}, func(name string) error {
+		removed = append(removed, name)
+		return nil
+	}, &Options{Tag: "inline"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel := func(names []string) string {
+		for i, name := range names {
+			names[i], _ = filepath.Rel(root, name)
+			names[i] = filepath.ToSlash(names[i])
+		}
+		return strings.Join(names, ",")
+	}
+	if got, want := rel(written), "p/kernel.go,p/q/kernel.go"; got != want {
+		t.Errorf("inlined %s, want %s", got, want)
+	}
+	if got, want := rel(removed), "p/gone_inlined.go"; got != want {
+		t.Errorf("removed %s, want %s", got, want)
+	}
+}
+
+// An output kept in another directory names its source by its path from
+// there, and is not removed as stale.
+func TestInlineTreeOutputDir(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "src", "k.go")
+	output := filepath.Join(root, "out", "k_inlined.go")
+	for _, dir := range []string{filepath.Dir(source), filepath.Dir(output)} {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src := "package k\n\nfunc f(s float64) float64 {\n\tfor i_ := 0; i_ < 2; i_++ {\n\t\ts += 1\n\t}\n\treturn s\n}\n"
+	if err := ioutil.WriteFile(source, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	w, err := os.Create(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = InlineFile(source, w, nil)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	inlined, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := generatedHeader + " from ../src/k.go. DO NOT EDIT.\n"; !strings.HasPrefix(string(inlined), want) {
+		t.Errorf("output headed by %q, want %q", strings.SplitAfter(string(inlined), "\n")[0], want)
+	}
+	var removed []string
+	err = InlineTree(root, func(name string) (io.WriteCloser, error) {
+		return new(closeBuffer), nil
+	}, func(name string) error {
+		removed = append(removed, name)
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) > 0 {
+		t.Errorf("removed %v, whose source exists", removed)
+	}
+}
+
+func TestInlineFiles(t *testing.T) {
+	dir := t.TempDir()
+	var names []string
+	for i := 0; i < 8; i++ {
+		src := fmt.Sprintf("package p\n\nfunc f(s float64) float64 {\n\tfor i_ := 0; i_ < %d; i_++ {\n"+
+			"\t\ts += float64(i_)\n\t}\n\treturn s\n}\n", i)
+		if i == 3 || i == 5 {
+			src = "package p\n\nfunc f() {\n" // A syntax error
+		}
+		names = append(names, filepath.Join(dir, fmt.Sprintf("f%d.go", i)))
+		if err := ioutil.WriteFile(names[i], []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var mu sync.Mutex
+	outs := make(map[string]*closeBuffer)
+	err := InlineFiles(names, func(name string) (io.WriteCloser, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		outs[name] = new(closeBuffer)
+		return outs[name], nil
+	}, &Options{Jobs: 3})
+	if err == nil || !strings.HasPrefix(err.Error(), names[3]+":3:12: ") ||
+		!strings.Contains(err.Error(), "\n"+names[5]+":3:12: ") {
+		t.Errorf("errors not joined in the order of the files: %v", err)
+	}
+	if len(outs) != 6 || outs[names[3]] != nil {
+		t.Fatalf("got %d outputs, want those of the 6 files without errors", len(outs))
+	}
+	if out := outs[names[7]].String(); !strings.HasPrefix(out, generatedHeader) || !strings.Contains(out, "s += float64(6)") {
+		t.Errorf("file not inlined:\n%s", out)
+	}
+}
+
+func BenchmarkInlineKernel(b *testing.B) {
+	src := kernelSource(100, 3)
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		if err := Inline(src, ioutil.Discard, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The kernels of the depth benchmarks, in which only the local functions
+// are inlineable, since the previous algorithm cannot inline the top level
+// function mad_.
+func depthKernel(depth int) []byte {
+	return bytes.ReplaceAll(kernelSource(100, depth), []byte("mad_"), []byte("mad"))
+}
+
+// Inlines kernels whose local functions are nested ever deeper, in a
+// single traversal of the source.
+func BenchmarkInlineDepth(b *testing.B) {
+	for _, depth := range []int{1, 2, 4, 8, 16} {
+		src := depthKernel(depth)
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				if err := Inline(src, ioutil.Discard, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// The baseline of BenchmarkInlineDepth: the same kernels inlined by the
+// previous algorithm, which parses the whole source again after each
+// cycle of changes.
+func BenchmarkReparseDepth(b *testing.B) {
+	for _, depth := range []int{1, 2, 4, 8, 16} {
+		src := depthKernel(depth)
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				// The source is copied, since reparse writes over it
+				if err := reparse.Inline(append([]byte(nil), src...), ioutil.Discard, DefaultFilter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
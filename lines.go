@@ -0,0 +1,96 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	. "go/ast"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Returns the //line directive giving the position of the line after it,
+// or "" if the position is not known. Files in the directory of the source
+// are named by their base names, since the inlined file is written next to
+// its source.
+func (m *BlockVisitor) lineDirective(pos token.Position) string {
+	if !pos.IsValid() || pos.Filename == "" {
+		return ""
+	}
+	name := pos.Filename
+	if filepath.Dir(name) == filepath.Dir(m.tfile.Name()) {
+		name = filepath.Base(name)
+	}
+	return "//line " + name + ":" + strconv.Itoa(pos.Line)
+}
+
+// Returns the //line directive of the source line following the node.
+func (m *BlockVisitor) directiveAfter(n Node) string {
+	pos := m.fset.Position(m.origin(n).End())
+	pos.Line += m.lineOffsets[pos.Filename] + 1
+	return m.directiveOf(pos)
+}
+
+// Returns the //line directive of a position, or "" unless directives
+// are written.
+func (m *BlockVisitor) directiveOf(pos token.Position) string {
+	if !m.lines {
+		return ""
+	}
+	return m.lineDirective(pos)
+}
+
+// Writes text to out, followed by the pending directive once a line of
+// the text ends. A directive ending with a newline is pending at the start
+// of a line, and is written before the text instead, unless the text is
+// empty. Returns the directive if it is still pending.
+func writeRestoring(out io.StringWriter, text, directive string) string {
+	if strings.HasSuffix(directive, "\n") {
+		if text == "" {
+			return directive
+		}
+		out.WriteString(directive)
+		directive = ""
+	}
+	if directive != "" {
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			out.WriteString(text[:i+1])
+			out.WriteString(directive + "\n")
+			text, directive = text[i+1:], ""
+		}
+	}
+	out.WriteString(text)
+	return directive
+}
+
+// Returns the directive of the line following the package clause of an
+// inlined file, so that lines may be inserted after the package clause.
+// The file is parsed from the inlined source, so that its positions are
+// mapped by the directives of the source. The directive ends the inserted
+// lines, since the rest of the source starts with the end of the line of
+// the package clause.
+func (m *BlockVisitor) afterPackageClause(fset *token.FileSet, f *File) string {
+	pos := fset.Position(f.Name.End())
+	pos.Line++
+	return m.directiveOf(pos)
+}
+
+// Returns the source with the directive of its first line prepended, if
+// directives are written.
+func (m *BlockVisitor) headDirective(src []byte) []byte {
+	d := m.directiveOf(token.Position{Filename: m.tfile.Name(), Line: 1 + m.lineOffsets[m.tfile.Name()]})
+	if d == "" {
+		return src
+	}
+	var b Buffer
+	b.WriteString(d + "\n")
+	b.Write(src)
+	return b.Bytes()
+}
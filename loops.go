@@ -0,0 +1,110 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	"fmt"
+	. "go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+)
+
+// Test if the loop has an integer loop counter variable with
+// static bounds and simple incrementer. If the loop declares a counter
+// matching the filter, but cannot be unwound, the reason why is returned.
+func isUnwindable(f *ForStmt, fileFilter *regexp.Regexp) (
+	canUnwind bool, startVal, endVal int, identName string, why string) {
+	assign, ok := f.Init.(*AssignStmt)
+	if !ok {
+		return
+	}
+	// Test for single assignment from interger literal
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || assign.Tok != token.DEFINE {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*Ident)
+	if !ok || len(fileFilter.FindString(ident.Name)) == 0 {
+		return
+	}
+	identName = ident.Name
+	bLit, ok := assign.Rhs[0].(*BasicLit)
+	if !ok || bLit.Kind != token.INT {
+		why = "the start is not an integer literal"
+		return
+	}
+	var err error
+	startVal, err = strconv.Atoi(bLit.Value)
+	if err != nil {
+		why = "the start " + bLit.Value + " is out of range"
+		return
+	}
+	// Test for simple conditional
+	why = "the condition is not " + identName + " < or <= an integer literal"
+	binExrp, ok := f.Cond.(*BinaryExpr)
+	if !ok || (binExrp.Op != token.LSS && binExrp.Op != token.LEQ) {
+		return
+	}
+	ident2, ok := binExrp.X.(*Ident)
+	if !ok || ident2.Name != ident.Name {
+		return
+	}
+	blit2, ok := binExrp.Y.(*BasicLit)
+	if !ok || blit2.Kind != token.INT {
+		why = "the bound is not an integer literal"
+		return
+	}
+	endVal, err = strconv.Atoi(blit2.Value)
+	if err != nil {
+		why = "the bound " + blit2.Value + " is out of range"
+		return
+	}
+	if binExrp.Op == token.LEQ {
+		endVal++
+	}
+	// Test incrementer
+	why = "the post statement is not " + identName + "++"
+	inds, ok := f.Post.(*IncDecStmt)
+	if !ok {
+		return
+	}
+	ident3, ok := inds.X.(*Ident)
+	if !ok || ident3.Name != ident.Name || inds.Tok != token.INC {
+		return
+	}
+	canUnwind, why = true, ""
+	return
+}
+
+// Unwinds for statements conforming to strict static loop requirements.
+// Each iteration is a copy of the loop body, in which the loop counter is
+// substituted by its value. The copies are enclosed in blocks if the body
+// declares anything.
+var unwindStaticLoop BlockOperator = func(f *BlockStmt, m *BlockVisitor) {
+	for _, statement := range f.List {
+		switch sm := statement.(type) {
+		case *ForStmt:
+			canUnwind, startVal, endVal, identName, why := isUnwindable(sm, m.funcNameFilter)
+			if n := endVal - startVal; canUnwind && m.maxUnwind > 0 && n > m.maxUnwind {
+				canUnwind, why = false, fmt.Sprintf("it has %d iterations, more than the limit of %d", n, m.maxUnwind)
+			}
+			if why != "" {
+				m.rejected(sm, "loop", identName, why)
+			}
+			if canUnwind {
+				var unwound []Stmt
+				for i := startVal; i < endVal; i++ {
+					subset := map[string]Expr{identName: &BasicLit{Kind: token.INT, Value: strconv.Itoa(i)}}
+					body := m.Substitute(sm.Body, subset).(*BlockStmt)
+					unwound = append(unwound, spliceable(body)...)
+				}
+				m.Replace(sm, "unwound", unwound...)
+				m.expanded(sm, "loop", identName)
+			}
+		}
+	}
+}
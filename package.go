@@ -0,0 +1,191 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// InlinePackage inlines the files of the package in dir that are only
+// built with the build tag of the options, 'generate' by default, for any
+// platform, as InlineFile inlines a file built for any platform. The top
+// level inlineable functions of all of the package's files, other than the
+// files written by inliner, are candidates for inlining into each of them,
+// and the files are type checked together. Once all of the files are
+// inlined, the result of each file is written, headed like the output of
+// InlineFile, to the writer that create returns for the file name. The
+// SourceName of the options is not used.
+func InlinePackage(dir string, create func(fileName string) (io.WriteCloser, error), opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	// The files to inline are selected by their constraints alone, so that
+	// those built for other platforms are inlined too
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	plain, err := build.Default.ImportDir(dir, 0)
+	if _, ok := err.(*build.NoGoError); err != nil && !ok {
+		return err
+	}
+	built := make(map[string]bool)
+	for _, name := range append(plain.GoFiles, plain.TestGoFiles...) {
+		built[name] = true
+	}
+
+	fset := token.NewFileSet()
+	var inputs []*source
+	var others []*source
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			continue
+		}
+		fileName := filepath.Join(dir, name)
+		src, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		if isGenerated(src) {
+			continue // The candidates are in the source of the file
+		}
+		if built[name] {
+			f, err := parser.ParseFile(fset, fileName, src, parser.AllErrors|parser.ParseComments)
+			if err != nil {
+				return err
+			}
+			others = append(others, &source{name: fileName, src: src, file: f})
+			continue
+		}
+		if end, _ := generateConstraint(TrimPrefix(src, []byte("\uFEFF")), opts.tag()); end == 0 {
+			continue // The file is not built with the tag
+		}
+		in, err := parseSource(fset, fileName, src, opts.tag())
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, in)
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("%s: no files with the %s build tag", dir, opts.tag())
+	}
+
+	results := make([]*Buffer, len(inputs))
+	outs := make([]io.Writer, len(inputs))
+	for i, in := range inputs {
+		in.header = header(in.name)
+		results[i] = new(Buffer)
+		outs[i] = results[i]
+	}
+	if err := inlineSources(fset, inputs, others, outs, opts); err != nil {
+		return err
+	}
+	for i, in := range inputs {
+		if err := writeResult(create, in.name, results[i].Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InlineFiles inlines each of the named files on its own, like InlineFile,
+// and writes the result of each to the writer that create returns for the
+// file name. Up to opts.Jobs files are inlined at once, each by its own
+// BlockVisitor. A file's writer is only created once the file is inlined,
+// so the outputs of files that fail are left as they were. The errors of
+// the files are joined in the order of the names, and each names its file.
+func InlineFiles(names []string, create func(fileName string) (io.WriteCloser, error), opts *Options) error {
+	fileOpts := Options{}
+	if opts != nil {
+		fileOpts = *opts
+	}
+	jobs := fileOpts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	if fileOpts.Explain != nil {
+		fileOpts.Explain = &lockedWriter{w: fileOpts.Explain}
+	}
+	if fileOpts.Diff != nil {
+		fileOpts.Diff = &lockedWriter{w: fileOpts.Diff}
+	}
+	if fileOpts.Report != nil {
+		fileOpts.Report = &lockedWriter{w: fileOpts.Report}
+	}
+	errs := make([]error, len(names))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for j := 0; j < jobs && j < len(names); j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = inlineNamed(names[i], create, fileOpts)
+			}
+		}()
+	}
+	for i := range names {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Inlines a file of InlineFiles, prefixing an error without a position
+// with the name of the file.
+func inlineNamed(name string, create func(fileName string) (io.WriteCloser, error), opts Options) error {
+	var result Buffer
+	err := InlineFile(name, &result, &opts)
+	if err == nil {
+		err = writeResult(create, name, result.Bytes())
+	}
+	var inlineErr *InlineError
+	if err != nil && !errors.As(err, &inlineErr) { // An InlineError holds the name
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return err
+}
+
+// A writer shared by the files that InlineFiles inlines at once
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// Writes a result to the writer that create returns for the file name,
+// which is closed.
+func writeResult(create func(fileName string) (io.WriteCloser, error), name string, result []byte) error {
+	w, err := create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(result)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
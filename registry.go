@@ -0,0 +1,136 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// A registered BlockOperator
+type registration struct {
+	name     string
+	priority int
+	op       BlockOperator
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []registration // Sorted by priority
+)
+
+// The priorities of the BlockOperators included with inliner. They leave
+// room for other operators to be registered in between.
+const (
+	FunctInlinePriority      = 100
+	UnwindStaticLoopPriority = 200
+	ContractInlinePriority   = 300
+	AssertInlinePriority     = 400
+)
+
+func init() {
+	Register("functInline", FunctInlinePriority, functInline)
+	Register("unwindStaticLoop", UnwindStaticLoopPriority, unwindStaticLoop)
+	Register("contractInline", ContractInlinePriority, contractInline)
+	Register("assertInline", AssertInlinePriority, assertInline)
+}
+
+// Register makes a BlockOperator available under a name. The registered
+// operators are applied to each block in order of priority, lowest first,
+// and operators of equal priority in the order they were registered. Since
+// a statement replaced by one operator is left as it is by the others, the
+// priority decides which operator gets to go first.
+// Register panics if the name is already registered.
+func Register(name string, priority int, op BlockOperator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, r := range registry {
+		if r.name == name {
+			panic("inliner: Register called twice for operator " + name)
+		}
+	}
+	registry = append(registry, registration{name, priority, op})
+	sort.SliceStable(registry, func(i, j int) bool {
+		return registry[i].priority < registry[j].priority
+	})
+}
+
+// Removes the registration of an operator, if it is registered.
+func unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i, r := range registry {
+		if r.name == name {
+			registry = append(registry[:i:i], registry[i+1:]...)
+			return
+		}
+	}
+}
+
+// Registered returns the names of the registered BlockOperators in the
+// order they are applied.
+func Registered() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, len(registry))
+	for i, r := range registry {
+		names[i] = r.name
+	}
+	return names
+}
+
+// Operators returns the registered BlockOperators in the order they are
+// applied. If enable is not empty, only the operators it names are
+// returned. The operators named by disable are left out. An unknown name
+// is an error.
+func Operators(enable, disable []string) ([]BlockOperator, error) {
+	regs, err := registered(enable, disable)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]BlockOperator, len(regs))
+	for i, r := range regs {
+		ops[i] = r.op
+	}
+	return ops, nil
+}
+
+// Returns the registrations of the operators selected like Operators.
+func registered(enable, disable []string) ([]registration, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	known := make(map[string]bool, len(registry))
+	for _, r := range registry {
+		known[r.name] = true
+	}
+	selected := make(map[string]bool, len(registry))
+	for _, name := range enable {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown operator %q", name)
+		}
+		selected[name] = true
+	}
+	if len(enable) == 0 {
+		for name := range known {
+			selected[name] = true
+		}
+	}
+	for _, name := range disable {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown operator %q", name)
+		}
+		delete(selected, name)
+	}
+	var regs []registration
+	for _, r := range registry {
+		if selected[r.name] {
+			regs = append(regs, r)
+		}
+	}
+	return regs, nil
+}
@@ -0,0 +1,140 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	"encoding/json"
+	. "go/ast"
+	"io"
+)
+
+// A Report describes the inlining of a source file: the transformations
+// made by the operators and the outcome of each inlining candidate.
+type Report struct {
+	Source          string           `json:"source"`
+	Transformations []Transformation `json:"transformations"`
+	Candidates      []Candidate      `json:"candidates"`
+}
+
+// A Transformation is the replacement of a statement by an operator. The
+// source range is that of the statement, or of the statement it copies,
+// such as a statement of an inlined body. The output range holds the lines
+// of the new statements in the inlined file, and is nil if the statement
+// was removed.
+type Transformation struct {
+	Operator string           `json:"operator,omitempty"`
+	Name     string           `json:"name,omitempty"` // The function called, the loop counter or the variable assigned
+	Source   Range            `json:"source"`
+	Output   *Range           `json:"output,omitempty"`
+	Nested   []Transformation `json:"nested,omitempty"` // The transformations of the new statements
+}
+
+// A Range of lines of a file, and of columns for source ranges.
+type Range struct {
+	File        string `json:"file,omitempty"`
+	StartLine   int    `json:"startLine"`
+	StartColumn int    `json:"startColumn,omitempty"`
+	EndLine     int    `json:"endLine"`
+	EndColumn   int    `json:"endColumn,omitempty"`
+}
+
+// A Candidate for inlining: a function, call, loop, assertion or contract,
+// with the number of times it was expanded, or why it was rejected.
+type Candidate struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Rejected string `json:"rejected,omitempty"`
+}
+
+// Writes the report of a source, as a line of JSON. The spliced source
+// holds the edits, and the result is the output, whose lines the lines of
+// the spliced source are mapped to.
+func (m *BlockVisitor) writeReport(w io.Writer, name string, spliced, result []byte) error {
+	starts, ends := lineMap(spliced, result)
+	output := func(first, last int) *Range {
+		first, end := min(first, len(starts)-1), min(last+1, len(ends)-1)
+		return &Range{StartLine: starts[first] + 1, EndLine: max(ends[end], starts[first]+1)}
+	}
+	r := Report{Source: name, Transformations: []Transformation{}, Candidates: []Candidate{}}
+	for _, e := range m.edits {
+		if e.rendered {
+			r.Transformations = append(r.Transformations, m.transformation(e, 0, output))
+		}
+	}
+	for _, e := range m.sortedExplanations() {
+		r.Candidates = append(r.Candidates, Candidate{File: e.pos.Filename, Line: e.pos.Line, Column: e.pos.Column,
+			Kind: e.kind, Name: e.name, Count: e.count, Rejected: e.why})
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// Returns the transformation of a rendered edit, whose lines are counted
+// from line0 of the spliced source. The output function maps the first and
+// last lines of the spliced source to a range of the output.
+func (m *BlockVisitor) transformation(e *edit, line0 int, output func(first, last int) *Range) Transformation {
+	t := Transformation{Operator: e.op, Name: label(e.old), Source: m.sourceRange(e.old)}
+	if e.first <= e.last {
+		t.Output = output(line0+e.first, line0+e.last)
+	}
+	for _, n := range e.nested {
+		if n.rendered {
+			t.Nested = append(t.Nested, m.transformation(n, line0+e.line0, output))
+		}
+	}
+	return t
+}
+
+// Returns the range of the source node that a node copies.
+func (m *BlockVisitor) sourceRange(n Node) Range {
+	orig := m.origin(n)
+	if !orig.Pos().IsValid() {
+		return Range{}
+	}
+	start, end := m.fset.Position(orig.Pos()), m.fset.Position(orig.End())
+	offset := m.lineOffsets[start.Filename]
+	return Range{File: start.Filename, StartLine: start.Line + offset, StartColumn: start.Column,
+		EndLine: end.Line + offset, EndColumn: end.Column}
+}
+
+// Maps the lines of a text to those of the text it was turned into, by
+// the differences of the texts. Returns the first and the last line of the
+// new text at the start of each line of the old text, and at its end. The
+// lines are counted from zero.
+func lineMap(old, new []byte) (starts, ends []int) {
+	a := splitLines(old)
+	starts, ends = make([]int, len(a)+1), make([]int, len(a)+1)
+	for i := range starts {
+		starts[i] = -1
+	}
+	i, j := 0, 0
+	note := func() {
+		if starts[i] < 0 {
+			starts[i] = j
+		}
+		ends[i] = j
+	}
+	note()
+	for _, l := range diffLines(a, splitLines(new)) {
+		if l.kind != '+' {
+			i++
+		}
+		if l.kind != '-' {
+			j++
+		}
+		note()
+	}
+	return starts, ends
+}
@@ -1,4 +1,4 @@
-// +build generate
+//go:build generate
 
 package main
 
@@ -43,3 +43,25 @@ func runAssertNumTest(numStr1, numStr2 string) (rerr error) {
 	deny_(err2, `rerr = err2; return`)
 	return
 }
+
+type point struct{ x, y int }
+
+// Arguments that are not booleans are compared with their zero value.
+// Each argument that is zero, or not nil for err, counts one failure.
+func runAssertZeroTest(s string, n int, f float64, p point, err error) (fails int) {
+	affirm_(s, "fails++")
+	affirm_(n, "fails++")
+	affirm_(f, "fails++")
+	affirm_(p, "fails++")
+	deny_(err, "fails++")
+	return
+}
+
+// Every argument but the trailing action string is a condition. The
+// conditions are checked in order, and the index of the first one that
+// fails is returned.
+func runAssertMultiTest(a, b int, err error) (failed int) {
+	affirm_(a > 0, b > 0, a < b, "return $index")
+	deny_(err, a == b, `return 3 + $index`)
+	return -1
+}
@@ -1,10 +1,15 @@
-// This file is generated by inliner. DO NOT EDIT.
-// Source file: asserts.go
+// Code generated by inliner from asserts.go. DO NOT EDIT.
+
 package main
 
+import (
+	inlinerfmt "fmt"
+	inlinerio "io"
+	inlineratomic "sync/atomic"
+)
+
 import (
 	"errors"
-	//"fmt"
 	"strconv"
 )
 
@@ -15,32 +20,44 @@ func runAssertFlowTest() (sum int) {
 loop:
 	for i := 0; i < 16; i++ {
 		/* deny_(i == 10, "continue") /* inlined assert */
+		inlineratomic.AddUint64(&assertStats_asserts[0].Evals, 1)
 		if i == 10 {
+			inlineratomic.AddUint64(&assertStats_asserts[0].Fails, 1)
 			continue
 		} /* */
 		numStr = append(numStr, strconv.Itoa(i))
 		/* affirm_(numStr) /* inlined assert */
+		inlineratomic.AddUint64(&assertStats_asserts[1].Evals, 1)
 		if numStr == nil {
+			inlineratomic.AddUint64(&assertStats_asserts[1].Fails, 1)
 			return
 		} /* */
 		/* deny_(i == 14, "break loop") /* inlined assert */
+		inlineratomic.AddUint64(&assertStats_asserts[2].Evals, 1)
 		if i == 14 {
+			inlineratomic.AddUint64(&assertStats_asserts[2].Fails, 1)
 			break loop
 		} /* */
 	}
 	//fmt.Println("len nums ", len(numStr))
 	/* affirm_(len(numStr) == 14) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[3].Evals, 1)
 	if (len(numStr) == 14) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[3].Fails, 1)
 		return
 	} /* */
 	for _, s := range numStr {
 		n, err := strconv.Atoi(s)
 		/* deny_(err, "break") /* inlined assert */
+		inlineratomic.AddUint64(&assertStats_asserts[4].Evals, 1)
 		if err != nil {
+			inlineratomic.AddUint64(&assertStats_asserts[4].Fails, 1)
 			break
 		} /* */
 		/* affirm_(n >= 0) /* inlined assert */
+		inlineratomic.AddUint64(&assertStats_asserts[5].Evals, 1)
 		if (n >= 0) == false {
+			inlineratomic.AddUint64(&assertStats_asserts[5].Fails, 1)
 			return
 		} /* */
 		sum += n
@@ -53,28 +70,138 @@ loop:
 func runAssertNumTest(numStr1, numStr2 string) (rerr error) {
 	number, err := strconv.Atoi(numStr1)
 	/* deny_(err, `return err`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[6].Evals, 1)
 	if err != nil {
+		inlineratomic.AddUint64(&assertStats_asserts[6].Fails, 1)
 		return err
 	} /* */ // Return if err is not nil
 	errNgt := errors.New("number not gt 0")
 	/* affirm_(number > 0, `return errNgt`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[7].Evals, 1)
 	if (number > 0) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[7].Fails, 1)
 		return errNgt
 	} /* */ // Return if number is not gt zero
 	number2, err2 := strconv.Atoi(numStr2)
 	/* deny_(err2, `return err2`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[8].Evals, 1)
 	if err2 != nil {
+		inlineratomic.AddUint64(&assertStats_asserts[8].Fails, 1)
 		return err2
 	} /* */ // Return if err is not nil
 	errNum := errors.New("number2 greater than number") // Return if number2 > number
 	/* affirm_(number2 > number, `return errNum`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[9].Evals, 1)
 	if (number2 > number) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[9].Fails, 1)
 		return errNum
 	} /* */
 	/* deny_(err2, `rerr = err2; return`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[10].Evals, 1)
 	if err2 != nil {
+		inlineratomic.AddUint64(&assertStats_asserts[10].Fails, 1)
 		rerr = err2
 		return
 	} /* */
 	return
 }
+
+type point struct{ x, y int }
+
+// Arguments that are not booleans are compared with their zero value.
+// Each argument that is zero, or not nil for err, counts one failure.
+func runAssertZeroTest(s string, n int, f float64, p point, err error) (fails int) {
+	/* affirm_(s, "fails++") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[11].Evals, 1)
+	if s == "" {
+		inlineratomic.AddUint64(&assertStats_asserts[11].Fails, 1)
+		fails++
+	} /* */
+	/* affirm_(n, "fails++") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[12].Evals, 1)
+	if n == 0 {
+		inlineratomic.AddUint64(&assertStats_asserts[12].Fails, 1)
+		fails++
+	} /* */
+	/* affirm_(f, "fails++") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[13].Evals, 1)
+	if f == 0 {
+		inlineratomic.AddUint64(&assertStats_asserts[13].Fails, 1)
+		fails++
+	} /* */
+	/* affirm_(p, "fails++") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[14].Evals, 1)
+	if p == (point{}) {
+		inlineratomic.AddUint64(&assertStats_asserts[14].Fails, 1)
+		fails++
+	} /* */
+	/* deny_(err, "fails++") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[15].Evals, 1)
+	if err != nil {
+		inlineratomic.AddUint64(&assertStats_asserts[15].Fails, 1)
+		fails++
+	} /* */
+	return
+}
+
+// Every argument but the trailing action string is a condition. The
+// conditions are checked in order, and the index of the first one that
+// fails is returned.
+func runAssertMultiTest(a, b int, err error) (failed int) {
+	/* affirm_(a > 0, b > 0, a < b, "return $index") /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[16].Evals, 1)
+	if (a > 0) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[16].Fails, 1)
+		return 0
+	} else if (b > 0) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[16].Fails, 1)
+		return 1
+	} else if (a < b) == false {
+		inlineratomic.AddUint64(&assertStats_asserts[16].Fails, 1)
+		return 2
+	} /* */
+	/* deny_(err, a == b, `return 3 + $index`) /* inlined assert */
+	inlineratomic.AddUint64(&assertStats_asserts[17].Evals, 1)
+	if err != nil {
+		inlineratomic.AddUint64(&assertStats_asserts[17].Fails, 1)
+		return 3 + 0
+	} else if a == b {
+		inlineratomic.AddUint64(&assertStats_asserts[17].Fails, 1)
+		return 3 + 1
+	} /* */
+	return -1
+}
+
+// assertStats_asserts counts the evaluations and failures of each inlined assertion.
+var assertStats_asserts = [...]struct {
+	Pos          string
+	Evals, Fails uint64
+}{
+	{Pos: "asserts.go:16"},
+	{Pos: "asserts.go:18"},
+	{Pos: "asserts.go:19"},
+	{Pos: "asserts.go:22"},
+	{Pos: "asserts.go:25"},
+	{Pos: "asserts.go:26"},
+	{Pos: "asserts.go:36"},
+	{Pos: "asserts.go:38"},
+	{Pos: "asserts.go:40"},
+	{Pos: "asserts.go:42"},
+	{Pos: "asserts.go:43"},
+	{Pos: "asserts.go:52"},
+	{Pos: "asserts.go:53"},
+	{Pos: "asserts.go:54"},
+	{Pos: "asserts.go:55"},
+	{Pos: "asserts.go:56"},
+	{Pos: "asserts.go:64"},
+	{Pos: "asserts.go:65"},
+}
+
+// dumpAssertStats_asserts writes the counts of assertStats_asserts to w.
+func dumpAssertStats_asserts(w inlinerio.Writer) {
+	for i := range assertStats_asserts {
+		s := &assertStats_asserts[i]
+		inlinerfmt.Fprintf(w, "%s: evaluated %d, failed %d\n", s.Pos,
+			inlineratomic.LoadUint64(&s.Evals), inlineratomic.LoadUint64(&s.Fails))
+	}
+}
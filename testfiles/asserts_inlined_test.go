@@ -0,0 +1,52 @@
+// Code generated by inliner from asserts_test.go. DO NOT EDIT.
+
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Without an explicit action, asserts in a function with a *testing.T or
+// *testing.B parameter are inlined as calls to Fatalf.
+func TestTestingAsserts(t *testing.T) {
+	n, err := strconv.Atoi("3141")
+	/* deny_(err) /* inlined assert */
+	if err != nil {
+		t.Fatalf("deny_(err) failed: err = %v", err)
+	} /* */
+	/* affirm_(n == 3141) /* inlined assert */
+	if (n == 3141) == false {
+		t.Fatalf("affirm_(n == 3141) failed: n = %v", n)
+	} /* */
+	/* affirm_(n%1000 == 141) /* inlined assert */
+	if (n%1000 == 141) == false {
+		t.Fatalf("affirm_(n%%1000 == 141) failed: n%%1000 = %v", n%1000)
+	} /* */
+	got, want := runAssertFlowTest(), 100
+	/* affirm_(got == want) /* inlined assert */
+	if (got == want) == false {
+		t.Fatalf("affirm_(got == want) failed: got = %v, want = %v", got, want)
+	} /* */
+	/* deny_(got != want, "t.FailNow()") /* inlined assert */
+	if got != want {
+		t.FailNow()
+	} /* */
+	/* affirm_(n > 0, got > 0, want > 0) /* inlined assert */
+	if (n > 0) == false {
+		t.Fatalf("affirm_(n > 0, got > 0, want > 0) failed at condition 0 (n > 0): n = %v", n)
+	} else if (got > 0) == false {
+		t.Fatalf("affirm_(n > 0, got > 0, want > 0) failed at condition 1 (got > 0): got = %v", got)
+	} else if (want > 0) == false {
+		t.Fatalf("affirm_(n > 0, got > 0, want > 0) failed at condition 2 (want > 0): want = %v", want)
+	} /* */
+}
+
+func BenchmarkTestingAsserts(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		/* affirm_(runAssertFlowTest() == 100) /* inlined assert */
+		if (runAssertFlowTest() == 100) == false {
+			b.Fatalf("affirm_(runAssertFlowTest() == 100) failed")
+		} /* */
+	}
+}
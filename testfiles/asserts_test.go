@@ -0,0 +1,27 @@
+//go:build generate
+
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Without an explicit action, asserts in a function with a *testing.T or
+// *testing.B parameter are inlined as calls to Fatalf.
+func TestTestingAsserts(t *testing.T) {
+	n, err := strconv.Atoi("3141")
+	deny_(err)
+	affirm_(n == 3141)
+	affirm_(n%1000 == 141)
+	got, want := runAssertFlowTest(), 100
+	affirm_(got == want)
+	deny_(got != want, "t.FailNow()")
+	affirm_(n > 0, got > 0, want > 0)
+}
+
+func BenchmarkTestingAsserts(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		affirm_(runAssertFlowTest() == 100)
+	}
+}
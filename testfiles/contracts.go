@@ -0,0 +1,36 @@
+//go:build generate
+
+package main
+
+import (
+	"errors"
+	"math"
+)
+
+var errDivZero = errors.New("division by zero")
+
+// The precondition panics on a negative argument and the postcondition
+// checks the named result after the body has run.
+func sqrtContract(x float64) (r float64) {
+	pre_(x >= 0)
+	post_(math.Abs(r*r-x) < 1e-9)
+	r = math.Sqrt(x)
+	return
+}
+
+// The failure actions return an error instead of panicking. The deferred
+// postcondition can overwrite the named results.
+func divContract(a, b int) (q int, err error) {
+	pre_(b != 0, `return 0, errDivZero`)
+	post_(q*b+a%b == a, `q, err = 0, errors.New("bad quotient")`)
+	q = a / b
+	return
+}
+
+// The postcondition is not checked when the body panics, which passes on
+// the panic of an index out of range.
+func indexContract(xs []int, i int) (x int) {
+	post_(x > 0)
+	x = xs[i]
+	return
+}
@@ -0,0 +1,13 @@
+//go:build generate
+
+package main
+
+import "github.com/srwiley/Inliner/testfiles/vecmath"
+
+func runCrossPackage() (d vecmath.Vec, n float64) {
+	v := vecmath.Vec{1, 2, 2}
+	vecmath.Madd_(&d, v, 2)
+	vecmath.Norm_(&n, v)
+	vecmath.Count_()
+	return
+}
@@ -2,10 +2,14 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
+	"runtime"
 	"testing"
+
+	"github.com/srwiley/Inliner/testfiles/vecmath"
 )
 
 func DenyErr(err error, t *testing.T) {
@@ -110,6 +114,100 @@ func TestAssertNum(t *testing.T) {
 	fmt.Println("TestAssertNum passed")
 }
 
+func TestAssertZero(t *testing.T) {
+	fails := runAssertZeroTest("", 0, 0, point{}, errors.New("error"))
+	if fails != 5 {
+		DenyErr(errors.New(fmt.Sprintln("Failures not equal to 5 as expected", fails)), t)
+	}
+	fails = runAssertZeroTest("3141", 3, 0.1, point{0, 1}, nil)
+	if fails != 0 {
+		DenyErr(errors.New(fmt.Sprintln("Failures not equal to 0 as expected", fails)), t)
+	}
+	fmt.Println("TestAssertZero passed")
+}
+
+func TestAssertMulti(t *testing.T) {
+	for i, c := range []struct {
+		a, b   int
+		err    error
+		failed int
+	}{
+		{1, 2, nil, -1},
+		{0, 2, nil, 0},
+		{1, 0, nil, 1},
+		{0, 0, nil, 0},
+		{2, 1, nil, 2},
+		{1, 2, errors.New("error"), 3},
+	} {
+		failed := runAssertMultiTest(c.a, c.b, c.err)
+		if failed != c.failed {
+			DenyErr(errors.New(fmt.Sprintln("Case", i, "failed condition", failed, "expected", c.failed)), t)
+		}
+	}
+	fmt.Println("TestAssertMulti passed")
+}
+
+func TestAssertStats(t *testing.T) {
+	before := assertStats_asserts
+	runAssertZeroTest("", 0, 0, point{}, errors.New("error")) // All five asserts fail
+	var evals, fails uint64
+	for i, s := range assertStats_asserts {
+		evals += s.Evals - before[i].Evals
+		fails += s.Fails - before[i].Fails
+	}
+	if evals != 5 || fails != 5 {
+		DenyErr(errors.New(fmt.Sprintln("Counts not equal to 5 as expected", evals, fails)), t)
+	}
+	var b bytes.Buffer
+	dumpAssertStats_asserts(&b)
+	if !bytes.HasPrefix(b.Bytes(), []byte("asserts.go:16: evaluated ")) {
+		DenyErr(errors.New(fmt.Sprintln("Unexpected dump", b.String())), t)
+	}
+	fmt.Println("TestAssertStats passed")
+}
+
+func TestContracts(t *testing.T) {
+	if r := sqrtContract(16); r != 4 {
+		DenyErr(errors.New(fmt.Sprintln("Square root not equal to 4 as expected", r)), t)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				DenyErr(errors.New("Precondition did not panic as expected"), t)
+			}
+		}()
+		sqrtContract(-1) // This fails the precondition
+	}()
+	q, err := divContract(7, 2)
+	DenyErr(err, t)
+	if q != 3 {
+		DenyErr(errors.New(fmt.Sprintln("Quotient not equal to 3 as expected", q)), t)
+	}
+	_, err = divContract(7, 0) // This fails the precondition
+	AffirmErr(err, t)
+	func() {
+		defer func() {
+			if _, ok := recover().(runtime.Error); !ok {
+				DenyErr(errors.New("Postcondition replaced the panic of the body"), t)
+			}
+		}()
+		indexContract(nil, 1) // This panics before the postcondition is checked
+	}()
+	fmt.Println("TestContracts passed")
+}
+
+func TestCrossPackage(t *testing.T) {
+	count := vecmath.Count() // The counter is kept by the package between runs
+	d, n := runCrossPackage()
+	if d != (vecmath.Vec{2, 4, 4}) || n != 3 {
+		DenyErr(errors.New(fmt.Sprintln("Unexpected vector and norm", d, n)), t)
+	}
+	if vecmath.Count() != count+1 {
+		DenyErr(errors.New("Count_ not called once as expected"), t)
+	}
+	fmt.Println("TestCrossPackage passed")
+}
+
 func Benchmark1_2xLocalNotInlined(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		compoundNotInlined()
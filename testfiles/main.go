@@ -1,12 +1,12 @@
 package main
 
-//go:generate -command inline ../inliner
-//go:generate inline -out asserts_inlined.go -in asserts.go
-//go:generate gofmt -w=true asserts_inlined.go
-//go:generate inline -out localFunctions_inlined.go -in localFunctions.go
-//go:generate gofmt -w=true localFunctions_inlined.go
+//go:generate -command inline go run ../cmd/inliner
+//go:generate inline -assertstats -out asserts_inlined.go -in asserts.go
+//go:generate inline -out contracts_inlined.go -in contracts.go
+//go:generate inline -out asserts_inlined_test.go -in asserts_test.go
+//go:generate inline -lines -out localFunctions_inlined.go -in localFunctions.go
 //go:generate inline -out staticLoop_inlined.go -in staticLoop.go
-//go:generate gofmt -w=true staticLoop.go
+//go:generate inline -out crossPackage_inlined.go -in crossPackage.go
 
 func main() {
 	runDoubleLoop()
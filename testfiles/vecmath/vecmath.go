@@ -0,0 +1,60 @@
+// Package vecmath holds inlineable helpers used by crossPackage.go.
+package vecmath
+
+import "math"
+
+// A vector of three dimensions
+type Vec [3]float64
+
+var count int
+
+// Adds k times v to d
+func Madd_(d *Vec, v Vec, k float64) {
+	for i := range d {
+		d[i] += v[i] * k
+	}
+}
+
+// Sets n to the length of v
+func Norm_(n *float64, v Vec) {
+	*n = math.Sqrt(Dot(v, v))
+}
+
+// Returns the dot product of u and v
+func Dot(u, v Vec) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
+
+// Counts a call. The unexported counter keeps it from being inlined.
+func Count_() {
+	count++
+}
+
+// Returns the number of calls counted
+func Count() int {
+	return count
+}
+
+// A counter whose count is unexported
+type Counter struct {
+	n int
+}
+
+// Increments c. The unexported field keeps it from being inlined.
+func Inc_(c *Counter) {
+	c.n++
+}
+
+// Resets c. The unexported method keeps it from being inlined.
+func Reset_(c *Counter) {
+	c.reset()
+}
+
+func (c *Counter) reset() {
+	c.n = 0
+}
+
+// Returns the count of c
+func (c *Counter) N() int {
+	return c.n
+}
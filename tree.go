@@ -0,0 +1,117 @@
+// Simple Go language function inliner
+// Copyright (C) 2015  Steven R. Wiley
+// Use of this source code is governed by
+// the GNU GENERAL PUBLIC LICENSE
+// found in the LICENSE file.
+
+package inliner
+
+import (
+	. "bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InlineTree inlines the packages of the directory tree rooted at root
+// that have files built only with the build tag of the options, each with
+// InlinePackage. Like the ./... pattern of the Go tool, the directories
+// named testdata or vendor, those starting with a dot or an underscore
+// and those of other modules are skipped. Unless remove is nil, it is
+// called with the name of each file written by inliner whose source no
+// longer exists. The errors of all of the packages are joined.
+func InlineTree(root string, create func(fileName string) (io.WriteCloser, error),
+	remove func(fileName string) error, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if path != root {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, dir := range dirs {
+		tagged, stale, err := scanDir(dir, opts.tag())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if tagged {
+			errs = append(errs, InlinePackage(dir, create, opts))
+		}
+		if remove != nil {
+			for _, name := range stale {
+				errs = append(errs, remove(name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reads the Go files of a directory, returning whether any of them is only
+// built with the tag, and the names of the files written by inliner whose
+// sources no longer exist.
+func scanDir(dir, tag string) (tagged bool, stale []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+			continue
+		}
+		name := filepath.Join(dir, e.Name())
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return false, nil, err
+		}
+		if source, ok := generatedFrom(src); ok {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(source))); errors.Is(err, fs.ErrNotExist) {
+				stale = append(stale, name)
+			}
+			continue
+		}
+		if end, _ := generateConstraint(src, tag); end > 0 {
+			tagged = true
+		}
+	}
+	return tagged, stale, nil
+}
+
+// Returns the source file named by the header of a file written by
+// inliner, if it names one, by its slash separated path from the directory
+// of the file.
+func generatedFrom(src []byte) (string, bool) {
+	prefix := []byte(generatedHeader + " from ")
+	if !HasPrefix(src, prefix) {
+		return "", false
+	}
+	line := src[len(prefix):]
+	if i := IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	name, ok := CutSuffix(line, []byte(". DO NOT EDIT."))
+	if !ok || len(name) == 0 {
+		return "", false
+	}
+	return string(name), true
+}